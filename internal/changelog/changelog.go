@@ -0,0 +1,118 @@
+package changelog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pinterb/go-semver/internal/conventional"
+)
+
+// Unreleased is the version of a release that hasn't been tagged yet
+const Unreleased = "Unreleased"
+
+const header = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+
+// sections are the Keep a Changelog sections, in the order they are rendered
+var sections = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security"}
+
+// DefaultSections maps commit types to the Keep a Changelog section they are
+// listed under. Commits with other types are left out of the changelog.
+var DefaultSections = map[string]string{
+	"feat":      "Added",
+	"perf":      "Changed",
+	"refactor":  "Changed",
+	"deprecate": "Deprecated",
+	"revert":    "Removed",
+	"fix":       "Fixed",
+	"security":  "Security",
+}
+
+// Release is a version and the commits that went into it
+type Release struct {
+	// Version is the released version or Unreleased
+	Version string
+	// Date is when the version was released. It isn't rendered when zero
+	Date    time.Time
+	Commits []*conventional.Commit
+}
+
+// Render writes a Keep a Changelog style Markdown document for a list of
+// releases, newest first. DefaultSections is used when types is nil.
+func Render(w io.Writer, releases []Release, types map[string]string) error {
+	if _, err := fmt.Fprint(w, header); err != nil {
+		return err
+	}
+
+	for _, r := range releases {
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+		if err := RenderRelease(w, r, types); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderRelease writes the Keep a Changelog style Markdown section of a
+// single release. DefaultSections is used when types is nil.
+func RenderRelease(w io.Writer, r Release, types map[string]string) error {
+	if types == nil {
+		types = DefaultSections
+	}
+
+	grouped := make(map[string][]*conventional.Commit)
+	for _, c := range r.Commits {
+		if s, ok := types[c.Type]; ok {
+			grouped[s] = append(grouped[s], c)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("## [" + r.Version + "]")
+	if !r.Date.IsZero() {
+		b.WriteString(" - " + r.Date.Format("2006-01-02"))
+	}
+	b.WriteString("\n")
+
+	for _, s := range sections {
+		if len(grouped[s]) == 0 {
+			continue
+		}
+
+		b.WriteString("\n### " + s + "\n\n")
+		for _, c := range grouped[s] {
+			b.WriteString("- " + entry(c) + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// entry returns the changelog line of a commit
+func entry(c *conventional.Commit) string {
+	var b strings.Builder
+	if c.Breaking {
+		b.WriteString("**BREAKING:** ")
+	}
+	if c.Scope != "" {
+		b.WriteString("**" + c.Scope + ":** ")
+	}
+	b.WriteString(c.Description)
+	if c.BreakingNote != "" {
+		b.WriteString(" (" + strings.ReplaceAll(c.BreakingNote, "\n", " ") + ")")
+	}
+	if len(c.Hash) >= 7 {
+		b.WriteString(" (" + c.Hash[:7] + ")")
+	}
+	return b.String()
+}
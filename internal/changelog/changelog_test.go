@@ -0,0 +1,82 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pinterb/go-semver/internal/conventional"
+)
+
+func parse(t *testing.T, hash string, msg string) *conventional.Commit {
+	c, err := conventional.Parse(msg)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	c.Hash = hash
+	return c
+}
+
+func TestRender(t *testing.T) {
+	releases := []Release{
+		{
+			Version: Unreleased,
+			Commits: []*conventional.Commit{
+				parse(t, "aaaaaaaaaa", "docs: update readme"),
+			},
+		},
+		{
+			Version: "1.1.0",
+			Date:    time.Date(2022, time.September, 19, 9, 18, 48, 0, time.UTC),
+			Commits: []*conventional.Commit{
+				parse(t, "bbbbbbbbbb", "feat(api): add pagination"),
+				parse(t, "cccccccccc", "fix: handle empty tags"),
+				parse(t, "dddddddddd", "refactor!: rename flags\n\nBREAKING CHANGE: -x is now -y"),
+				parse(t, "eeeeeeeeee", "chore: bump deps"),
+			},
+		},
+	}
+
+	var b strings.Builder
+	if err := Render(&b, releases, nil); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := header + `
+## [Unreleased]
+
+## [1.1.0] - 2022-09-19
+
+### Added
+
+- **api:** add pagination (bbbbbbb)
+
+### Changed
+
+- **BREAKING:** rename flags (-x is now -y) (ddddddd)
+
+### Fixed
+
+- handle empty tags (ccccccc)
+`
+	if b.String() != expected {
+		t.Fatalf("expected changelog:\n%s\nfound:\n%s", expected, b.String())
+	}
+}
+
+func TestRenderReleaseTypes(t *testing.T) {
+	r := Release{
+		Version: "2.0.0",
+		Commits: []*conventional.Commit{parse(t, "", "docs: update readme")},
+	}
+
+	var b strings.Builder
+	if err := RenderRelease(&b, r, map[string]string{"docs": "Changed"}); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := "## [2.0.0]\n\n### Changed\n\n- update readme\n"
+	if b.String() != expected {
+		t.Fatalf("expected release:\n%s\nfound:\n%s", expected, b.String())
+	}
+}
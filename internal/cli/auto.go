@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pinterb/go-semver/internal/conventional"
+	"github.com/pinterb/go-semver/internal/git"
+	"github.com/pinterb/go-semver/pkg/semver"
+)
+
+// autoIncrement is the increment level that picks the release type from the
+// commits since the latest tag
+const autoIncrement = "auto"
+
+// latestTag returns the name of the latest valid semver tag in the git repo or
+// an empty string if there is none
+func latestTag() (string, error) {
+	tags, err := git.TagsWithOptions(gdir, tagOptions())
+	if err != nil {
+		return "", err
+	}
+
+	vs := semver.ParseVersions(tags, parseMode())
+	if len(vs) == 0 {
+		return "", nil
+	}
+
+	sort.Sort(vs)
+	return tagPrefix + vs[len(vs)-1].Original(), nil
+}
+
+// autoReleaseType picks the release type for the current version from the
+// Conventional Commits since the latest tag. It reports false when there are
+// no commits since the latest tag.
+func autoReleaseType(current string) (semver.ReleaseType, bool, error) {
+	tag, err := latestTag()
+	if err != nil {
+		return 0, false, err
+	}
+
+	since := ""
+	if tag != "" {
+		since = "refs/tags/" + tag
+	}
+
+	gcs, err := git.Commits(gdir, since, reachFrom)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(gcs) == 0 {
+		if explain {
+			fmt.Fprintf(os.Stderr, "no commits since %s\n", tag)
+		}
+		return 0, false, nil
+	}
+
+	commits := parseCommits(gcs)
+	a, err := conventional.Analyze(current, commits, releaseTypes())
+	if err != nil {
+		return 0, false, err
+	}
+
+	if explain {
+		from := "the first commit"
+		if tag != "" {
+			from = tag
+		}
+		fmt.Fprintf(os.Stderr, "%s release required by %d of %d commits since %s:\n", a.ReleaseType, len(a.Commits), len(commits), from)
+		for _, c := range a.Commits {
+			fmt.Fprintf(os.Stderr, "  %s %s\n", c.Hash[:7], c.Header)
+		}
+	}
+
+	return a.ReleaseType, true, nil
+}
+
+// parseCommits parses git commit messages as Conventional Commits. Messages
+// that aren't conventional commits only have their header set.
+func parseCommits(gcs []git.Commit) []*conventional.Commit {
+	commits := make([]*conventional.Commit, len(gcs))
+	for i, gc := range gcs {
+		c, err := conventional.Parse(gc.Message)
+		if err != nil {
+			c = &conventional.Commit{Header: strings.SplitN(strings.TrimSpace(gc.Message), "\n", 2)[0]}
+		}
+		c.Hash = gc.Hash
+		commits[i] = c
+	}
+	return commits
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"text/template"
+
+	"github.com/pinterb/go-semver/internal/git"
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+)
+
+var (
+	setBuild    string
+	appendBuild string
+	stripBuild  bool
+)
+
+// buildNumberEnv are the environment variables CI services keep the build
+// number in, in the order they are looked up
+var buildNumberEnv = []string{
+	"BUILD_NUMBER",           // Jenkins, TeamCity
+	"GITHUB_RUN_NUMBER",      // GitHub Actions
+	"CI_PIPELINE_IID",        // GitLab CI
+	"CIRCLE_BUILD_NUM",       // CircleCI
+	"BUILDKITE_BUILD_NUMBER", // Buildkite
+	"TRAVIS_BUILD_NUMBER",    // Travis CI
+	"BUILD_BUILDID",          // Azure Pipelines
+	"DRONE_BUILD_NUMBER",     // Drone
+}
+
+// buildData resolves the placeholders of build metadata templates. Git
+// placeholders describe the revision being versioned, HEAD unless a tag
+// revision is given.
+type buildData struct{}
+
+// SHA returns the short commit hash
+func (buildData) SHA() (string, error) {
+	c, err := git.ResolveCommit(gdir, tagRef)
+	if err != nil {
+		return "", err
+	}
+	return c.Hash[:7], nil
+}
+
+// Commit returns the full commit hash
+func (buildData) Commit() (string, error) {
+	c, err := git.ResolveCommit(gdir, tagRef)
+	if err != nil {
+		return "", err
+	}
+	return c.Hash, nil
+}
+
+// Commits returns the number of commits reachable from the revision
+func (buildData) Commits() (int, error) {
+	cs, err := git.Commits(gdir, "", tagRef)
+	if err != nil {
+		return 0, err
+	}
+	return len(cs), nil
+}
+
+// Timestamp returns the commit time in UTC, as YYYYMMDDhhmmss
+func (buildData) Timestamp() (string, error) {
+	c, err := git.ResolveCommit(gdir, tagRef)
+	if err != nil {
+		return "", err
+	}
+	return c.When.UTC().Format("20060102150405"), nil
+}
+
+// BuildNumber returns the build number of the CI service, or an error when
+// not running in a known CI service
+func (buildData) BuildNumber() (string, error) {
+	for _, env := range buildNumberEnv {
+		if n := os.Getenv(env); n != "" {
+			return n, nil
+		}
+	}
+	return "", errors.New("no CI build number found in the environment")
+}
+
+// addBuildFlags adds the flags changing the build metadata of a result
+func addBuildFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&setBuild, "build", "", "Set the build metadata of the result. Can be a template using {{.SHA}}, {{.Commit}}, {{.Commits}}, {{.Timestamp}}, {{.BuildNumber}} or {{env \"NAME\"}}, e.g. sha.{{.SHA}}")
+	cmd.Flags().StringVar(&appendBuild, "append-build", "", "Append identifiers to the build metadata of the result. Can be a template like --build")
+	cmd.Flags().BoolVar(&stripBuild, "strip-build", false, "Strip the build metadata of the result")
+}
+
+// buildFlagsSet reports whether the build metadata of a result is changed
+func buildFlagsSet() bool {
+	return setBuild != "" || appendBuild != "" || stripBuild
+}
+
+// validBuild returns an error if more than one change of the build metadata
+// is requested
+func validBuild() error {
+	n := 0
+	for _, set := range []bool{setBuild != "", appendBuild != "", stripBuild} {
+		if set {
+			n++
+		}
+	}
+
+	if n > 1 {
+		return errors.New("only one of --build, --append-build or --strip-build may be specified")
+	}
+	return nil
+}
+
+// applyBuild changes the build metadata of a version as requested
+func applyBuild(v string) (string, error) {
+	switch {
+	case setBuild != "":
+		meta, err := renderBuild(setBuild)
+		if err != nil {
+			return "", err
+		}
+		return semver.SetMetadata(v, meta)
+
+	case appendBuild != "":
+		meta, err := renderBuild(appendBuild)
+		if err != nil {
+			return "", err
+		}
+		return semver.AppendMetadata(v, meta)
+
+	case stripBuild:
+		return semver.StripMetadata(v)
+	}
+	return v, nil
+}
+
+// renderBuild resolves the placeholders of a build metadata template
+func renderBuild(text string) (string, error) {
+	t, err := template.New("build").Funcs(template.FuncMap{"env": os.Getenv}).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, buildData{}); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pinterb/go-semver/internal/manifest"
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+)
+
+var writeFiles bool
+
+// bumpedOutput is the structured output of a version bumped in a manifest file
+type bumpedOutput struct {
+	File     string `json:"file" yaml:"file"`
+	Previous string `json:"previous" yaml:"previous"`
+	Version  string `json:"version" yaml:"version"`
+}
+
+// bumpOutput is the structured output of a bump
+type bumpOutput struct {
+	ReleaseType string         `json:"releaseType" yaml:"releaseType"`
+	Preid       string         `json:"preid,omitempty" yaml:"preid,omitempty"`
+	Written     bool           `json:"written" yaml:"written"`
+	Files       []bumpedOutput `json:"files" yaml:"files"`
+}
+
+func newBump() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bump",
+		Short: "Increment the versions held in manifest files",
+		Long: `
+Read the version held in each manifest file, increment it and print the
+result. With --write the files are rewritten in place, changing nothing
+but the versions, so formatting and comments are preserved. Either every
+file is updated or none is.
+
+A file is given as path[:field]. The format and default field follow
+from the file name:
+
+  package.json, *.json      version
+  Chart.yaml, *.yaml        version (e.g. Chart.yaml:appVersion)
+  Cargo.toml                package.version or workspace.package.version
+  pyproject.toml            project.version or tool.poetry.version
+  *.go                      the Version constant or variable
+  VERSION, *.txt            the first non-blank line
+`,
+		Example: `semver bump -f package.json -i=minor
+semver bump -f Chart.yaml -f Chart.yaml:appVersion -f VERSION --write`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := handleBump(cmd, args); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validBumpArgs(cmd, args); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().SortFlags = false
+
+	addFileFlag(cmd)
+
+	cmd.Flags().StringVarP(&incr, "increment", "i", "", "Level to increment the versions by. One of: major, minor, patch, premajor, preminor, prepatch, prerelease or build. (default patch)")
+
+	cmd.Flags().StringVar(&preid, "preid", "", predesc)
+
+	addBuildFlags(cmd)
+
+	cmd.Flags().BoolVarP(&writeFiles, "write", "w", false, "Rewrite the files with the incremented versions")
+
+	addModeFlags(cmd)
+
+	addOutputFlag(cmd)
+
+	cmd.Flags().BoolP("help", "h", false, "Help for bump")
+	return cmd
+}
+
+func validBumpArgs(cmd *cobra.Command, args []string) error {
+	if err := applyConfig(cmd); err != nil {
+		return err
+	}
+
+	if err := validOutput(); err != nil {
+		return err
+	}
+
+	if err := validMode(); err != nil {
+		return err
+	}
+
+	if err := validBuild(); err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		return errors.New("versions are not allowed when bumping files")
+	}
+
+	if len(files) == 0 {
+		files = configFiles()
+	}
+
+	if len(files) == 0 {
+		return errors.New("at least one file needs to be provided")
+	}
+
+	return nil
+}
+
+func handleBump(cmd *cobra.Command, args []string) error {
+	rt := semver.Patch
+	if incr != "" {
+		var err error
+		rt, err = semver.ToReleaseType(incr)
+		if err != nil {
+			return err
+		}
+	}
+
+	ins, err := inputs(args)
+	if err != nil {
+		return err
+	}
+
+	updates := make([]manifest.Update, 0, len(ins))
+	bumped := make([]bumpedOutput, 0, len(ins))
+	lines := make([]string, 0, len(ins))
+	for _, in := range ins {
+		if _, err := semver.ParseVersion(in.raw, parseMode()); err != nil {
+			return fmt.Errorf("%s: %w", in.file, err)
+		}
+
+		nv, err := semver.Increment(in.raw, rt, preid)
+		if err != nil {
+			return fmt.Errorf("%s: %w", in.file, err)
+		}
+
+		nv, err = applyBuild(nv)
+		if err != nil {
+			return fmt.Errorf("%s: %w", in.file, err)
+		}
+
+		updates = append(updates, manifest.Update{Spec: manifest.ParseSpec(in.file), Version: nv})
+		bumped = append(bumped, bumpedOutput{File: in.file, Previous: in.raw, Version: nv})
+		lines = append(lines, fmt.Sprintf("%s: %s -> %s", in.file, in.raw, nv))
+	}
+
+	if writeFiles {
+		if err := manifest.Write(updates); err != nil {
+			return err
+		}
+	}
+
+	return printOutput(strings.Join(lines, "\n"), bumpOutput{
+		ReleaseType: rt.String(),
+		Preid:       preid,
+		Written:     writeFiles,
+		Files:       bumped,
+	})
+}
@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/pinterb/go-semver/internal/changelog"
+	"github.com/pinterb/go-semver/internal/git"
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+)
+
+var unreleasedOnly bool
+
+func newChangelog() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "changelog",
+		Short: "Generate a changelog from the commits between tags",
+		Long: `
+Generate a Keep a Changelog style Markdown document from the Conventional
+Commits between the valid semver tags of a local git repo. Commits are
+grouped by type: feat under Added, perf and refactor under Changed,
+deprecate under Deprecated, revert under Removed, fix under Fixed and
+security under Security. Other commits are left out.
+
+Commits since the latest tag are listed as Unreleased, unless an increment
+is given, in which case they are listed under the next version.
+`,
+		Example: `semver changelog > CHANGELOG.md
+semver changelog --unreleased -i=auto`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := handleChangelog(cmd, args); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				fmt.Fprintln(os.Stderr, errors.New("versions are not allowed when generating a changelog"))
+				os.Exit(2)
+			}
+			if err := applyConfig(cmd); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if err := validOutput(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if err := validMode(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().StringVarP(&gdir, "repo-dir", "r", "", "Local git repo to generate the changelog for (default current working directory)")
+
+	addTagFlags(cmd)
+
+	cmd.Flags().BoolVarP(&unreleasedOnly, "unreleased", "u", false, "Only render the commits since the latest tag")
+
+	cmd.Flags().StringVarP(&incr, "increment", "i", "", "List the commits since the latest tag under the latest version incremented by the specified level")
+	cmd.Flag("increment").NoOptDefVal = autoIncrement
+
+	cmd.Flags().StringVar(&preid, "preid", "", predesc)
+
+	addModeFlags(cmd)
+
+	addOutputFlag(cmd)
+
+	cmd.Flags().BoolP("help", "h", false, "Help for changelog")
+	return cmd
+}
+
+func handleChangelog(cmd *cobra.Command, args []string) error {
+	tags, err := git.ListTags(gdir, tagOptions())
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(tags))
+	dates := make(map[string]time.Time, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+		dates[t.Name] = t.Date
+	}
+
+	vs := semver.ParseVersions(names, parseMode())
+	sort.Sort(vs)
+
+	latest := ""
+	if len(vs) > 0 {
+		latest = tagPrefix + vs[len(vs)-1].Original()
+	}
+
+	// the commits of each release, followed by those since the latest one,
+	// split from a single walk of the history
+	revs := make([]string, 0, len(vs)+1)
+	for _, v := range vs {
+		revs = append(revs, "refs/tags/"+tagPrefix+v.Original())
+	}
+	if unreleasedOnly && len(revs) > 0 {
+		revs = revs[len(revs)-1:]
+	}
+	ranges, err := git.Ranges(gdir, append(revs, reachFrom))
+	if err != nil {
+		return err
+	}
+
+	next, err := unreleased(vs, ranges[len(ranges)-1])
+	if err != nil {
+		return err
+	}
+
+	if unreleasedOnly {
+		if outputFormat != outputText {
+			return printOutput("", newChangelogOutput([]changelog.Release{next}))
+		}
+		return changelog.RenderRelease(os.Stdout, next, nil)
+	}
+
+	releases := make([]changelog.Release, 0, len(vs)+1)
+	if len(next.Commits) > 0 || latest == "" {
+		releases = append(releases, next)
+	}
+
+	// releases are dated by their tags, so a release tagged after the fact
+	// shows when it was released
+	for i := len(vs) - 1; i >= 0; i-- {
+		releases = append(releases, changelog.Release{
+			Version: vs[i].String(),
+			Date:    dates[vs[i].Original()],
+			Commits: parseCommits(ranges[i]),
+		})
+	}
+
+	if outputFormat != outputText {
+		return printOutput("", newChangelogOutput(releases))
+	}
+	return changelog.Render(os.Stdout, releases, nil)
+}
+
+// unreleased returns the release of the commits since the latest of the
+// sorted tag versions
+func unreleased(vs semver.Collection, gcs []git.Commit) (changelog.Release, error) {
+	r := changelog.Release{Version: changelog.Unreleased}
+	current := "0.0.0"
+	if len(vs) > 0 {
+		current = vs[len(vs)-1].String()
+	}
+	r.Commits = parseCommits(gcs)
+
+	if incr == "" || len(gcs) == 0 {
+		return r, nil
+	}
+
+	var rt semver.ReleaseType
+	var err error
+	if incr == autoIncrement {
+		rt, _, err = autoReleaseType(current)
+	} else {
+		rt, err = semver.ToReleaseType(incr)
+	}
+	if err != nil {
+		return r, err
+	}
+
+	nv, err := semver.Increment(current, rt, preid)
+	if err != nil {
+		return r, err
+	}
+
+	r.Version = nv
+	r.Date = time.Now()
+	return r, nil
+}
+
+// commitOutput is the structured output of a commit in a release
+type commitOutput struct {
+	Hash        string `json:"hash" yaml:"hash"`
+	Type        string `json:"type,omitempty" yaml:"type,omitempty"`
+	Scope       string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Header      string `json:"header" yaml:"header"`
+	Breaking    bool   `json:"breaking" yaml:"breaking"`
+	Section     string `json:"section,omitempty" yaml:"section,omitempty"`
+}
+
+// releaseOutput is the structured output of a release in a changelog
+type releaseOutput struct {
+	Version string         `json:"version" yaml:"version"`
+	Date    string         `json:"date,omitempty" yaml:"date,omitempty"`
+	Commits []commitOutput `json:"commits" yaml:"commits"`
+}
+
+// changelogOutput is the structured output of a changelog
+type changelogOutput struct {
+	Releases []releaseOutput `json:"releases" yaml:"releases"`
+}
+
+// newChangelogOutput returns the structured output of a list of releases
+func newChangelogOutput(releases []changelog.Release) changelogOutput {
+	o := changelogOutput{Releases: make([]releaseOutput, len(releases))}
+	for i, r := range releases {
+		ro := releaseOutput{Version: r.Version, Commits: make([]commitOutput, len(r.Commits))}
+		if !r.Date.IsZero() {
+			ro.Date = r.Date.Format("2006-01-02")
+		}
+
+		for j, c := range r.Commits {
+			ro.Commits[j] = commitOutput{
+				Hash:        c.Hash,
+				Type:        c.Type,
+				Scope:       c.Scope,
+				Description: c.Description,
+				Header:      c.Header,
+				Breaking:    c.Breaking,
+				Section:     changelog.DefaultSections[c.Type],
+			}
+		}
+		o.Releases[i] = ro
+	}
+	return o
+}
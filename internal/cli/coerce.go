@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+)
+
+func newCoerce() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "coerce TEXT...",
+		Short: "Extract a version from arbitrary text",
+		Long: `
+Extract the first version-like substring from each text, such as
+release-2021-v3.4 or "foo 1.2 bar", and print it as a normalized
+version. Dotted or v prefixed numbers are preferred over a bare number,
+missing components are zero and any prerelease or build metadata is
+ignored.
+
+Exits with a status of 1 when a text contains no version.
+`,
+		Example: `semver coerce release-2021-v3.4 "foo 1.2 bar"`,
+		Run: func(cmd *cobra.Command, args []string) {
+			found, err := handleCoerce(cmd, args)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if !found {
+				os.Exit(1)
+			}
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				fmt.Fprintln(os.Stderr, errors.New("at least one text needs to be provided"))
+				os.Exit(2)
+			}
+			if err := validOutput(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().BoolVar(&keepV, "keep-v", false, "Keep the v prefix of versions that had one in the text")
+
+	addOutputFlag(cmd)
+
+	cmd.Flags().BoolP("help", "h", false, "Help for coerce")
+	return cmd
+}
+
+// handleCoerce prints the version extracted from each text and reports
+// whether every text contained one
+func handleCoerce(cmd *cobra.Command, args []string) (bool, error) {
+	coerced := make([]entry, 0, len(args))
+	rejected := make([]rejection, 0)
+	names := make([]string, 0, len(args))
+	for _, a := range args {
+		in := input{raw: a, source: sourceArg}
+		v, err := semver.Coerce(a)
+		if err != nil {
+			rejected = append(rejected, rejection{input: in, err: fmt.Errorf("%w in %q", err, a)})
+			continue
+		}
+
+		coerced = append(coerced, entry{input: in, version: v})
+		names = append(names, display(v))
+	}
+
+	if outputFormat == outputText {
+		for _, r := range rejected {
+			fmt.Fprintln(os.Stderr, r.err)
+		}
+		if len(names) > 0 {
+			fmt.Println(strings.Join(names, " "))
+		}
+		return len(rejected) == 0, nil
+	}
+
+	err := printOutput("", listOutput{
+		Versions: newVersionOutputs(coerced),
+		Rejected: newRejectedOutputs(rejected),
+	})
+	return len(rejected) == 0, err
+}
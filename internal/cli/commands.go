@@ -10,7 +10,7 @@ import (
 
 	"github.com/pinterb/go-semver/internal/crlf"
 	"github.com/pinterb/go-semver/internal/git"
-	"github.com/pinterb/go-semver/internal/semver"
+	"github.com/pinterb/go-semver/pkg/semver"
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
 	"sigs.k8s.io/release-utils/version"
@@ -22,9 +22,21 @@ var (
 	preid      string
 	defv       string
 	latestOnly bool
+	rawOutput  bool
+	keepV      bool
+	explain    bool
+	createTag  bool
+	tagRef     string
+	tagMsg     string
+	tagger     string
+	taggerMail string
+	pushRemote string
+	reachFrom  string
+	tagPrefix  string
+	tagPattern string
 
-	incrdesc = fmt.Sprintf("Increment a valid version by the specified level. Level can %sbe one of: major, minor, patch, premajor, preminor, prepatch, %sor prerelease. If more than one version is provided, then %sthe most current version is incremented.", crlf.Linebreak, crlf.Linebreak, crlf.Linebreak)
-	predesc  = fmt.Sprintf("Identifier to be used to prefix premajor, preminor, %sprepatch or prerelease version increments.", crlf.Linebreak)
+	incrdesc = fmt.Sprintf("Increment a valid version by the specified level. Level can %sbe one of: major, minor, patch, premajor, preminor, prepatch, %sprerelease or build. If more than one version is provided, then %sthe most current version is incremented. Use auto to pick %sthe level from the Conventional Commits since the latest %stag of the git repo.", crlf.Linebreak, crlf.Linebreak, crlf.Linebreak, crlf.Linebreak, crlf.Linebreak)
+	predesc  = fmt.Sprintf("Identifier to be used to prefix premajor, preminor, %sprepatch, prerelease or build version increments.", crlf.Linebreak)
 )
 
 func New() *cobra.Command {
@@ -73,22 +85,76 @@ to a valid next version.
 
 	cmd.Flags().StringVar(&preid, "preid", "", predesc)
 
+	addBuildFlags(cmd)
+
+	cmd.Flags().BoolVar(&explain, "explain", false, "Print the commits that drove an automatic increment to stderr")
+
 	cmd.Flags().StringVarP(&gdir, "repo-dir", "r", "", "Use tags from a local git repo as source of versions.")
 	cmd.Flag("repo-dir").NoOptDefVal = path
 
+	addTagFlags(cmd)
+
+	addRegistryFlag(cmd)
+
+	addHelmFlags(cmd)
+
+	addStreamFlags(cmd)
+
+	addFileFlag(cmd)
+
 	cmd.Flags().StringVarP(&defv, "default", "d", "", "Default version to use when no valid versions are provided")
 	cmd.Flag("default").NoOptDefVal = "0.0.0"
 
 	cmd.Flags().BoolVarP(&latestOnly, "latest-only", "l", false, "Only return the latest version")
 
+	cmd.Flags().BoolVar(&rawOutput, "raw", false, "Return versions as they were spelled in the input instead of normalized")
+	cmd.Flags().BoolVar(&keepV, "keep-v", false, "Keep the v prefix of versions that had one in the input")
+
+	addModeFlags(cmd)
+
+	addSchemeFlags(cmd)
+
+	cmd.Flags().BoolVarP(&createTag, "tag", "t", false, "Create the incremented version as a tag in the git repo")
+	cmd.Flags().StringVar(&tagRef, "tag-ref", "", "Revision to tag instead of HEAD")
+	cmd.Flags().StringVarP(&tagMsg, "tag-message", "m", "", "Create an annotated tag with the given message")
+	cmd.Flags().StringVar(&tagger, "tagger-name", "", "Name of the annotated tag creator (default user.name from the repo config)")
+	cmd.Flags().StringVar(&taggerMail, "tagger-email", "", "Email of the annotated tag creator (default user.email from the repo config)")
+
+	cmd.Flags().StringVar(&pushRemote, "push", "", "Push the created tag to the named remote")
+	cmd.Flag("push").NoOptDefVal = "origin"
+
+	addOutputFlag(cmd)
+
 	cmd.Flags().BoolP("help", "h", false, "Help for semver")
 
+	cmd.AddCommand(newSatisfies())
+	cmd.AddCommand(newDiff())
+	cmd.AddCommand(newChangelog())
+	cmd.AddCommand(newValidate())
+	cmd.AddCommand(newCoerce())
+	cmd.AddCommand(newBump())
+	cmd.AddCommand(newConfig())
+	cmd.AddCommand(newDescribe())
+	cmd.AddCommand(newPseudo())
+	cmd.AddCommand(newImageTags())
 	cmd.AddCommand(version.Version())
 	return cmd
 }
 
 func validArgs(cmd *cobra.Command, args []string) error {
-	if len(args) < 1 && gdir == "" {
+	if err := applyConfig(cmd); err != nil {
+		return err
+	}
+
+	if err := validOutput(); err != nil {
+		return err
+	}
+
+	if err := validMode(); err != nil {
+		return err
+	}
+
+	if len(args) < 1 && gdir == "" && registryRepo == "" && helmIndex == "" && len(files) == 0 && !readsStream(args) {
 		return errors.New("at least one version needs to be provided")
 	}
 
@@ -96,56 +162,240 @@ func validArgs(cmd *cobra.Command, args []string) error {
 		return errors.New("versions are not allowed when specifying a git repository")
 	}
 
+	if len(args) > 1 && registryRepo != "" {
+		return errors.New("versions are not allowed when specifying a registry repository")
+	}
+
+	if len(args) > 1 && helmIndex != "" {
+		return errors.New("versions are not allowed when specifying a chart repository index")
+	}
+
+	if err := validTagFilters(); err != nil {
+		return err
+	}
+
+	if err := validHelm(); err != nil {
+		return err
+	}
+
+	if err := validStream(args); err != nil {
+		return err
+	}
+
+	if incr == autoIncrement && gdir == "" {
+		return errors.New("an automatic increment requires a git repository")
+	}
+
+	if err := validBuild(); err != nil {
+		return err
+	}
+
+	if buildFlagsSet() && incr == "" {
+		return errors.New("changing the build metadata requires an increment")
+	}
+
+	if err := validScheme(); err != nil {
+		return err
+	}
+
+	if explain && incr != autoIncrement {
+		return errors.New("explain is only allowed with an automatic increment")
+	}
+
+	if createTag && (gdir == "" || incr == "") {
+		return errors.New("creating a tag requires both a git repository and an increment")
+	}
+
+	if !createTag && (pushRemote != "" || tagRef != "" || tagMsg != "") {
+		return errors.New("tag options are only allowed when creating a tag")
+	}
+
 	return nil
 }
 
 func handleVersions(cmd *cobra.Command, args []string) error {
-	var v2 []string
-	var err error
+	if customScheme() {
+		s, err := versionScheme()
+		if err != nil {
+			return err
+		}
+		return handleSchemeVersions(s, args)
+	}
+
+	// parse the versions as they're read, keeping only the latest when
+	// nothing else is output
+	c := newCollector(latestOnly || incr != "")
 	if defv != "" {
-		v2 = []string{defv}
-	} else {
-		v2 = []string{}
+		if err := c.add(input{raw: defv, source: sourceDefault}); err != nil {
+			return err
+		}
 	}
 
 	// use either passed in versions (i.e. args) or tags from git repo
-	v2 = append(v2, args...)
-	if gdir != "" {
-		v, err := git.Tags(gdir)
+	if err := eachInput(args, c.add); err != nil {
+		return err
+	}
+
+	// get sorted list of valid versions
+	valid, rejected := c.result()
+
+	if len(valid) == 0 {
+		if outputFormat != outputText {
+			return printOutput("", listOutput{Versions: newVersionOutputs(valid), Rejected: newRejectedOutputs(rejected)})
+		}
+		return nil
+	}
+
+	latest := valid[len(valid)-1]
+	if incr == "" {
+		if latestOnly {
+			valid = valid[len(valid)-1:]
+		}
+
+		names := make([]string, len(valid))
+		for i, e := range valid {
+			names[i] = display(e.version)
+		}
+
+		return printOutput(strings.Join(names, " "), listOutput{
+			Versions: newVersionOutputs(valid),
+			Rejected: newRejectedOutputs(rejected),
+		})
+	}
+
+	var rt semver.ReleaseType
+	var err error
+	if incr == autoIncrement {
+		var changed bool
+		rt, changed, err = autoReleaseType(latest.version.String())
+		if err != nil {
+			return err
+		}
+
+		// nothing to release
+		if !changed {
+			return printOutput(display(latest.version), listOutput{
+				Versions: newVersionOutputs(valid[len(valid)-1:]),
+				Rejected: newRejectedOutputs(rejected),
+			})
+		}
+	} else {
+		rt, err = semver.ToReleaseType(incr)
 		if err != nil {
 			return err
 		}
-		v2 = append(v2, v...)
 	}
 
-	// get sorted list of valid versions
-	valid, err := semver.SortedList(v2)
+	// increment current version, in its original spelling when asked to
+	// keep it
+	in := latest.version.String()
+	switch {
+	case rawOutput || keepV:
+		in = latest.version.Original()
+	case tagPrefix != "":
+		// tags keep the "v" of the tag they follow
+		in = latest.version.Styled()
+	}
+	nv, err := semver.Increment(in, rt, preid)
 	if err != nil {
 		return err
 	}
 
-	if len(valid) > 0 {
-		if incr == "" {
-			var fv string = strings.Join(valid, " ")
-			if latestOnly {
-				fv = valid[len(valid)-1]
-			}
-			fmt.Println(fv)
-		} else {
-			rt, err := semver.ToReleaseType(incr)
-			if err != nil {
-				return err
-			}
+	nv, err = applyBuild(nv)
+	if err != nil {
+		return err
+	}
+	nv = tagOptions().TagName(nv)
 
-			// increment current version
-			nv, err := semver.Increment(valid[len(valid)-1], rt, preid)
-			if err != nil {
-				return err
-			}
-			fmt.Println(nv)
+	// only tags with a "v" prefix are Go module versions
+	if latest.source == sourceGit && latest.version.Prefixed() {
+		if err := checkModuleVersion(strings.TrimPrefix(nv, tagPrefix)); err != nil {
+			return err
 		}
 	}
 
+	if createTag {
+		if err := tagVersion(nv, display(latest.version)); err != nil {
+			return err
+		}
+	}
+
+	return printOutput(nv, incrementOutput{
+		Base:        newVersionOutput(latest),
+		ReleaseType: rt.String(),
+		Preid:       preid,
+		Version:     nv,
+		Rejected:    newRejectedOutputs(rejected),
+	})
+}
+
+// display returns the spelling of a version to output
+func display(v *semver.Version) string {
+	s := v.String()
+	switch {
+	case rawOutput:
+		s = v.Original()
+	case keepV || tagPrefix != "":
+		// tags keep the "v" of the tag they were listed from
+		s = v.Styled()
+	}
+	return tagOptions().TagName(s)
+}
+
+// addTagFlags adds the flags that restrict the tags used from a git repo
+func addTagFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&reachFrom, "reachable-from", "", "Only use tags reachable from the given revision of the git repo")
+	cmd.Flag("reachable-from").NoOptDefVal = "HEAD"
+
+	cmd.Flags().StringVar(&tagPrefix, "tag-prefix", "", "Only use tags with the given prefix, e.g. services/api/, which is stripped before parsing and re-applied to output")
+	cmd.Flags().StringVar(&tagPattern, "tag-pattern", "", "Only use tags matching the given glob, or regular expression when wrapped in slashes")
+}
+
+// validTagFilters returns an error if tag filters are used without a source of
+// tags they apply to
+func validTagFilters() error {
+	if reachFrom != "" && gdir == "" {
+		return errors.New("reachable-from is only allowed when specifying a git repository")
+	}
+
+	if (tagPrefix != "" || tagPattern != "") && gdir == "" && registryRepo == "" {
+		return errors.New("tag filters are only allowed when specifying a git repository or a registry repository")
+	}
+	return nil
+}
+
+// tagOptions returns the options restricting the tags used from a git repo
+func tagOptions() *git.ListOptions {
+	return &git.ListOptions{
+		ReachableFrom: reachFrom,
+		Prefix:        tagPrefix,
+		Pattern:       tagPattern,
+	}
+}
+
+// tagVersion creates a tag for a version in the git repo, optionally pushing
+// it to a remote
+func tagVersion(v, previous string) error {
+	msg, err := tagMessage(v, previous)
+	if err != nil {
+		return err
+	}
+
+	opts := &git.TagOptions{
+		Ref:         tagRef,
+		Message:     msg,
+		TaggerName:  tagger,
+		TaggerEmail: taggerMail,
+	}
+	if err := git.CreateTag(gdir, v, opts); err != nil {
+		return fmt.Errorf("unable to create tag %s: %w", v, err)
+	}
+
+	if pushRemote != "" {
+		if err := git.PushTag(gdir, pushRemote, v); err != nil {
+			return fmt.Errorf("unable to push tag %s to %s: %w", v, pushRemote, err)
+		}
+	}
 	return nil
 }
 
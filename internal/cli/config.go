@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/pinterb/go-semver/internal/config"
+	"github.com/pinterb/go-semver/internal/conventional"
+	"github.com/pinterb/go-semver/internal/manifest"
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// cfg is the project configuration of the git repo
+var cfg = &config.Config{}
+
+// configOutput is the structured output of the effective settings
+type configOutput struct {
+	File         string            `json:"file" yaml:"file"`
+	TagPrefix    string            `json:"tagPrefix" yaml:"tagPrefix"`
+	Default      string            `json:"default" yaml:"default"`
+	Preid        string            `json:"preid" yaml:"preid"`
+	Strict       bool              `json:"strict" yaml:"strict"`
+	Files        []string          `json:"files" yaml:"files"`
+	ReleaseTypes map[string]string `json:"releaseTypes" yaml:"releaseTypes"`
+	TagMessage   string            `json:"tagMessage" yaml:"tagMessage"`
+	Describe     string            `json:"describeTemplate" yaml:"describeTemplate"`
+	Scheme       string            `json:"scheme" yaml:"scheme"`
+	Format       string            `json:"format" yaml:"format"`
+}
+
+// tagMessageData is given to the tag message template
+type tagMessageData struct {
+	Version  string
+	Previous string
+}
+
+func newConfig() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the project configuration",
+		Long: `
+Settings shared by every invocation in a project can be kept in a
+` + config.FileName + ` file at the root of its git repo. They are defaults
+for the flags of the same name, so flags always take precedence:
+
+  tagPrefix: services/api/
+  default: 0.0.0
+  preid: rc
+  strict: true
+  files:
+    - package.json
+    - charts/api/Chart.yaml:appVersion
+  releaseTypes:
+    docs: patch
+    deps: minor
+  tagMessage: "Release {{.Version}}"
+  describeTemplate: "dev.{{.Distance}}+g{{.SHA}}"
+  scheme: semver
+
+Files are relative to the configuration file and are bumped when no
+--file is given. Release types add to or override the default mapping of
+commit types used by automatic increments. The tag message template is
+given the Version and the Previous version and is used when a tag is
+created without --tag-message. The describe template is the default of
+the --template flag of describe. Projects using calendar versions set the
+scheme to calver along with a format, such as YYYY.0M.MICRO.
+`,
+		Example: "semver config show",
+	}
+
+	cmd.Flags().BoolP("help", "h", false, "Help for config")
+
+	cmd.AddCommand(newConfigShow())
+	return cmd
+}
+
+func newConfigShow() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective settings",
+		Long: `
+Print the settings in effect, i.e. the project configuration with any
+flags applied on top of it.
+`,
+		Example: `semver config show
+semver config show --preid beta -o json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := handleConfigShow(cmd, args); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				fmt.Fprintln(os.Stderr, errors.New("arguments are not allowed when showing the config"))
+				os.Exit(2)
+			}
+			if err := applyConfig(cmd); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if err := validOutput(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if err := validMode(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().StringVarP(&gdir, "repo-dir", "r", "", "Local git repo to find the configuration of (default current working directory)")
+	cmd.Flags().StringVar(&tagPrefix, "tag-prefix", "", "Prefix of the tags of the project")
+	cmd.Flags().StringVarP(&defv, "default", "d", "", "Default version to use when no valid versions are provided")
+	cmd.Flags().StringVar(&preid, "preid", "", predesc)
+	cmd.Flags().StringVar(&describeTemplate, "template", "", "Template of the prerelease identifiers and build metadata of development versions")
+	cmd.Flags().StringVar(&schemeName, "scheme", "", "Versioning scheme of the versions (default semver)")
+	cmd.Flags().StringVar(&schemeFormat, "format", "", "Format of the versions of the scheme")
+
+	addModeFlags(cmd)
+
+	addFileFlag(cmd)
+
+	addOutputFlag(cmd)
+
+	cmd.Flags().BoolP("help", "h", false, "Help for show")
+	return cmd
+}
+
+func handleConfigShow(cmd *cobra.Command, args []string) error {
+	types := make(map[string]string)
+	for ct, rt := range releaseTypes() {
+		types[ct] = rt.String()
+	}
+
+	fs := files
+	if len(fs) == 0 {
+		fs = configFiles()
+	}
+
+	scheme := strings.ToLower(schemeName)
+	if scheme == "" {
+		scheme = semver.SemVer.Name()
+	}
+
+	o := configOutput{
+		File:         cfg.Path,
+		TagPrefix:    tagPrefix,
+		Default:      defv,
+		Preid:        preid,
+		Strict:       strict,
+		Files:        fs,
+		ReleaseTypes: types,
+		TagMessage:   cfg.TagMessage,
+		Describe:     describeTemplate,
+		Scheme:       scheme,
+		Format:       schemeFormat,
+	}
+
+	b, err := yaml.Marshal(o)
+	if err != nil {
+		return err
+	}
+	return printOutput(strings.TrimSuffix(string(b), "\n"), o)
+}
+
+// applyConfig loads the project configuration of the git repo and uses its
+// settings as defaults for the flags of a command that weren't set
+func applyConfig(cmd *cobra.Command) error {
+	c, err := config.Discover(gdir)
+	if err != nil {
+		return err
+	}
+	cfg = c
+
+	defaults := map[string]string{
+		"tag-prefix": c.TagPrefix,
+		"default":    c.Default,
+		"preid":      c.Preid,
+		"template":   c.DescribeTemplate,
+		"scheme":     c.Scheme,
+		"format":     c.Format,
+	}
+	// the tag prefix only applies when versions are read from tags, which
+	// commands taking the git repo as an optional source may not do
+	if f := cmd.Flags().Lookup("repo-dir"); f != nil && f.NoOptDefVal != "" && gdir == "" && registryRepo == "" {
+		delete(defaults, "tag-prefix")
+	}
+	if c.Strict && !cmd.Flags().Changed("loose") {
+		defaults["strict"] = "true"
+	}
+
+	for name, value := range defaults {
+		f := cmd.Flags().Lookup(name)
+		if value == "" || f == nil || f.Changed {
+			continue
+		}
+
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("%s: %s: %w", c.Path, name, err)
+		}
+	}
+	return nil
+}
+
+// configFiles returns the manifest files of the project configuration,
+// relative to the working directory
+func configFiles() []string {
+	fs := make([]string, len(cfg.Files))
+	for i, f := range cfg.Files {
+		spec := manifest.ParseSpec(f)
+		if !filepath.IsAbs(spec.Path) {
+			spec.Path = filepath.Join(cfg.Dir(), spec.Path)
+		}
+		fs[i] = spec.String()
+	}
+	return fs
+}
+
+// releaseTypes returns the mapping of commit types to release types, the
+// defaults overridden by the project configuration
+func releaseTypes() map[string]semver.ReleaseType {
+	types := make(map[string]semver.ReleaseType, len(conventional.DefaultReleaseTypes))
+	for ct, rt := range conventional.DefaultReleaseTypes {
+		types[ct] = rt
+	}
+
+	// the configuration was checked when loaded
+	configured, _ := cfg.Types()
+	for ct, rt := range configured {
+		types[ct] = rt
+	}
+	return types
+}
+
+// tagMessage returns the message of a tag created for a version, rendering
+// the template of the project configuration when no message was given
+func tagMessage(version, previous string) (string, error) {
+	if tagMsg != "" || cfg.TagMessage == "" {
+		return tagMsg, nil
+	}
+
+	t, err := template.New("tagMessage").Parse(cfg.TagMessage)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, tagMessageData{Version: version, Previous: previous}); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/pinterb/go-semver/internal/git"
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+)
+
+// defaultDescribeTemplate is the template of development versions
+const defaultDescribeTemplate = "dev.{{.Distance}}+g{{.SHA}}{{if .Dirty}}.dirty{{end}}"
+
+var describeTemplate string
+
+// describeData is given to the describe template
+type describeData struct {
+	Tag       string
+	Version   string
+	Distance  int
+	SHA       string
+	Commit    string
+	Timestamp string
+	Dirty     bool
+}
+
+// describeOutput is the structured output of a description
+type describeOutput struct {
+	Tag      string `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Base     string `json:"base" yaml:"base"`
+	Distance int    `json:"distance" yaml:"distance"`
+	Commit   string `json:"commit" yaml:"commit"`
+	Dirty    bool   `json:"dirty" yaml:"dirty"`
+	Version  string `json:"version" yaml:"version"`
+}
+
+func newDescribe() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Print a development version of the commits past the nearest tag",
+		Long: `
+Print a git describe style development version, such as
+1.4.1-dev.7+g3f2a9c1 for the 7th commit past v1.4.0. The nearest valid
+semver tag reachable from HEAD is used, or 0.0.0 when there is none.
+The version of a tagged commit with a clean worktree is printed as is.
+
+The template renders the prerelease identifiers, optionally followed by
+a + and the build metadata. The identifiers start the prerelease of the
+next patch version of a release, or are appended to the prerelease of a
+prerelease, so development versions sort after the tag and before any
+version released after it. The template is given the Tag, its Version,
+the Distance in commits, the short SHA and full Commit hash, the commit
+Timestamp and whether the worktree is Dirty.
+`,
+		Example: `semver describe
+semver describe --template 'snapshot.{{.Distance}}.{{.Timestamp}}'`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := handleDescribe(cmd, args); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				fmt.Fprintln(os.Stderr, errors.New("versions are not allowed when describing a git repository"))
+				os.Exit(2)
+			}
+			if err := applyConfig(cmd); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if err := validOutput(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if err := validMode(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().StringVarP(&gdir, "repo-dir", "r", "", "Local git repo to describe (default current working directory)")
+
+	addTagFlags(cmd)
+
+	cmd.Flags().StringVar(&describeTemplate, "template", "", fmt.Sprintf("Template of the prerelease identifiers and build metadata (default %q)", defaultDescribeTemplate))
+
+	cmd.Flags().BoolVar(&keepV, "keep-v", false, "Keep the v prefix of the nearest tag")
+
+	addModeFlags(cmd)
+
+	addOutputFlag(cmd)
+
+	cmd.Flags().BoolP("help", "h", false, "Help for describe")
+	return cmd
+}
+
+func handleDescribe(cmd *cobra.Command, args []string) error {
+	rev := reachFrom
+	if rev == "" {
+		rev = "HEAD"
+	}
+
+	// tags that aren't reachable from the revision are never found by the
+	// walk of its history
+	opts := tagOptions()
+	opts.ReachableFrom = ""
+	tags, err := git.ListTags(gdir, opts)
+	if err != nil {
+		return err
+	}
+
+	// the valid versions of the tags
+	versions := make(map[string]*semver.Version, len(tags))
+	candidates := make([]git.Tag, 0, len(tags))
+	for _, t := range tags {
+		v, err := semver.ParseVersion(t.Name, parseMode())
+		if err != nil {
+			continue
+		}
+		versions[t.Name] = v
+		candidates = append(candidates, t)
+	}
+
+	// the nearest tag, the higher version when tags are as near
+	tag, distance, err := git.Describe(gdir, rev, candidates, func(a, b git.Tag) bool {
+		return versions[a.Name].GreaterThan(versions[b.Name])
+	})
+	if err != nil {
+		return err
+	}
+
+	base := versions[tag.Name]
+	if tag.Name == "" {
+		base, _ = semver.NewVersion("0.0.0")
+	}
+
+	head, err := git.ResolveCommit(gdir, rev)
+	if err != nil {
+		return err
+	}
+
+	dirty := false
+	if rev == "HEAD" {
+		dirty, err = git.Dirty(gdir)
+		if err != nil && !errors.Is(err, git.ErrIsBareRepository) {
+			return err
+		}
+	}
+
+	in := base.String()
+	if keepV {
+		in = base.Styled()
+	}
+
+	described := in
+	if distance > 0 || dirty || tag.Name == "" {
+		data := describeData{
+			Version:   base.String(),
+			Distance:  distance,
+			SHA:       head.Hash[:7],
+			Commit:    head.Hash,
+			Timestamp: head.When.UTC().Format("20060102150405"),
+			Dirty:     dirty,
+		}
+		if tag.Name != "" {
+			data.Tag = tagPrefix + tag.Name
+		}
+
+		described, err = describe(in, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	o := describeOutput{
+		Base:     base.String(),
+		Distance: distance,
+		Commit:   head.Hash,
+		Dirty:    dirty,
+		Version:  described,
+	}
+	if tag.Name != "" {
+		o.Tag = tagPrefix + tag.Name
+	}
+	return printOutput(described, o)
+}
+
+// describe renders the describe template and applies it to a version
+func describe(in string, data describeData) (string, error) {
+	text := describeTemplate
+	if text == "" {
+		text = defaultDescribeTemplate
+	}
+
+	t, err := template.New("describe").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	pre, meta := b.String(), ""
+	if i := strings.Index(pre, "+"); i >= 0 {
+		pre, meta = pre[:i], pre[i+1:]
+	}
+	return semver.Snapshot(in, pre, meta)
+}
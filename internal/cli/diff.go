@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+)
+
+func newDiff() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "diff VERSION VERSION",
+		Aliases: []string{"compare"},
+		Short:   "Compare two versions and report the difference level",
+		Long: `
+Compare two versions and print their ordering (-1, 0 or 1, depending on
+whether the first version is lower than, equal to or greater than the
+second) followed by the most significant component that differs. The
+component is one of: major, minor, patch, premajor, preminor, prepatch,
+prerelease or build. Nothing follows the ordering when the versions are
+identical.
+`,
+		Example: `semver diff 1.2.3 2.0.0
+semver compare v1.2.3 1.2.4-rc.0`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := handleDiff(cmd, args); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				fmt.Fprintln(os.Stderr, errors.New("exactly two versions need to be provided"))
+				os.Exit(2)
+			}
+			if err := applyConfig(cmd); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if err := validOutput(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if err := validMode(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			return nil
+		},
+	}
+
+	addModeFlags(cmd)
+
+	addOutputFlag(cmd)
+
+	cmd.Flags().BoolP("help", "h", false, "Help for diff")
+	return cmd
+}
+
+// diffOutput is the structured output of a comparison
+type diffOutput struct {
+	A     string `json:"a" yaml:"a"`
+	B     string `json:"b" yaml:"b"`
+	Order int    `json:"order" yaml:"order"`
+	Type  string `json:"type,omitempty" yaml:"type,omitempty"`
+}
+
+func handleDiff(cmd *cobra.Command, args []string) error {
+	for _, a := range args {
+		if _, err := semver.ParseVersion(a, parseMode()); err != nil {
+			return err
+		}
+	}
+
+	d, err := semver.Diff(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	text := fmt.Sprint(d.Order)
+	if d.Changed {
+		text = fmt.Sprint(d.Order, " ", d)
+	}
+
+	return printOutput(text, diffOutput{
+		A:     args[0],
+		B:     args[1],
+		Order: d.Order,
+		Type:  d.String(),
+	})
+}
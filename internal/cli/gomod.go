@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pinterb/go-semver/internal/git"
+	"github.com/pinterb/go-semver/pkg/semver"
+)
+
+// moduleDirective matches the module directive of a go.mod file
+var moduleDirective = regexp.MustCompile(`(?m)^module\s+"?([^\s"]+)"?`)
+
+// modulePath returns the path of the Go module the tags of the git repo
+// version, read from the go.mod in the directory named by the tag prefix,
+// or an empty string if there is none
+func modulePath() (string, error) {
+	root, err := git.Root(gdir)
+	if errors.Is(err, git.ErrIsBareRepository) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// tags of a nested module are prefixed with its directory
+	dir := strings.TrimSuffix(tagPrefix, "/")
+	if !strings.HasSuffix(tagPrefix, "/") {
+		dir = ""
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(dir), "go.mod"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	m := moduleDirective.FindSubmatch(b)
+	if m == nil {
+		return "", nil
+	}
+	return string(m[1]), nil
+}
+
+// checkModuleVersion returns an error if a version breaks the major version
+// suffix rule of the Go module of the git repo, if there is one
+func checkModuleVersion(v string) error {
+	path, err := modulePath()
+	if err != nil || path == "" {
+		return err
+	}
+	return semver.CheckPathMajor(v, path)
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+)
+
+var separator string
+
+// imageTagOutput is the structured output of an image tag
+type imageTagOutput struct {
+	Tag      string `json:"tag" yaml:"tag"`
+	Floating bool   `json:"floating" yaml:"floating"`
+	Push     bool   `json:"push" yaml:"push"`
+	Skipped  string `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+}
+
+// imageTagsOutput is the structured output of the image tags of a version
+type imageTagsOutput struct {
+	Version  string           `json:"version" yaml:"version"`
+	Tags     []imageTagOutput `json:"tags" yaml:"tags"`
+	Rejected []rejectedOutput `json:"rejected" yaml:"rejected"`
+}
+
+func newImageTags() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "image-tags VERSION [VERSION...]",
+		Short: "List the container image tags to push for a release",
+		Long: `
+List the tags a container image built for a release should be pushed as:
+the version itself, and the floating major.minor, major and latest tags
+when the version is the highest release of their line. The versions
+released before are passed as arguments after the version or taken from
+the tags of a local git repo or of a container registry repository.
+
+Floating tags are skipped for prereleases and for backports, such as a
+1.3.1 released after 1.4.0, and major version zero has no major tag.
+`,
+		Example: `semver image-tags 1.4.2 -r
+semver image-tags 1.4.2 --registry-repo ghcr.io/org/app
+ko build --tags=$(semver image-tags 1.4.2 -r --separator ,)`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := handleImageTags(cmd, args); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validImageTagsArgs(cmd, args); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			return nil
+		},
+	}
+
+	path, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().StringVarP(&gdir, "repo-dir", "r", "", "Use tags from a local git repo as the versions released before.")
+	cmd.Flag("repo-dir").NoOptDefVal = path
+
+	addTagFlags(cmd)
+
+	addRegistryFlag(cmd)
+
+	cmd.Flags().StringVarP(&separator, "separator", "s", " ", "Separator of the printed tags, e.g. , for ko")
+	cmd.Flags().BoolVar(&keepV, "keep-v", false, "Keep the v prefix of the version in its tags, except latest")
+
+	addModeFlags(cmd)
+
+	addOutputFlag(cmd)
+
+	cmd.Flags().BoolP("help", "h", false, "Help for image-tags")
+	return cmd
+}
+
+func validImageTagsArgs(cmd *cobra.Command, args []string) error {
+	if err := applyConfig(cmd); err != nil {
+		return err
+	}
+
+	if err := validOutput(); err != nil {
+		return err
+	}
+
+	if err := validMode(); err != nil {
+		return err
+	}
+
+	if len(args) < 1 {
+		return errors.New("a version needs to be provided")
+	}
+
+	if len(args) > 1 && gdir != "" {
+		return errors.New("versions are not allowed when specifying a git repository")
+	}
+
+	if len(args) > 1 && registryRepo != "" {
+		return errors.New("versions are not allowed when specifying a registry repository")
+	}
+
+	if err := validTagFilters(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func handleImageTags(cmd *cobra.Command, args []string) error {
+	v, err := semver.ParseVersion(args[0], parseMode())
+	if err != nil {
+		return err
+	}
+
+	valid, rejected, err := collectInputs(args[1:], false)
+	if err != nil {
+		return err
+	}
+	existing := make([]string, len(valid))
+	for i, e := range valid {
+		existing[i] = e.version.String()
+	}
+
+	tags, err := semver.ImageTags(v.String(), existing)
+	if err != nil {
+		return err
+	}
+
+	o := imageTagsOutput{
+		Version:  v.String(),
+		Tags:     make([]imageTagOutput, len(tags)),
+		Rejected: newRejectedOutputs(rejected),
+	}
+	names := make([]string, 0, len(tags))
+	for i, t := range tags {
+		name := t.Name
+		if keepV && v.Prefixed() && name != "latest" {
+			name = "v" + name
+		}
+
+		o.Tags[i] = imageTagOutput{
+			Tag:      name,
+			Floating: t.Floating,
+			Push:     t.Skipped == "",
+			Skipped:  t.Skipped,
+		}
+		if t.Skipped == "" {
+			names = append(names, name)
+		}
+	}
+
+	return printOutput(strings.Join(names, separator), o)
+}
@@ -0,0 +1,355 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/pinterb/go-semver/internal/git"
+	"github.com/pinterb/go-semver/internal/helm"
+	"github.com/pinterb/go-semver/internal/manifest"
+	"github.com/pinterb/go-semver/internal/registry"
+	"github.com/pinterb/go-semver/internal/stream"
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+)
+
+// sources of raw version values
+const (
+	sourceArg      = "arg"
+	sourceDefault  = "default"
+	sourceGit      = "git"
+	sourceFile     = "file"
+	sourceRegistry = "registry"
+	sourceHelm     = "helm"
+	sourceStdin    = "stdin"
+	sourceFromFile = "from-file"
+)
+
+var (
+	strict       bool
+	loose        bool
+	files        []string
+	registryRepo string
+	helmIndex    string
+	helmChart    string
+	helmApp      bool
+	readStdin    bool
+	fromFiles    []string
+	extract      string
+)
+
+// addFileFlag adds the flag reading versions from manifest files
+func addFileFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&files, "file", "f", nil, "Use the version held in a manifest file, given as path[:field], e.g. Chart.yaml:appVersion. Can be repeated.")
+}
+
+// addRegistryFlag adds the flag reading versions from the tags of a
+// container registry repository
+func addRegistryFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&registryRepo, "registry-repo", "", "Use tags from a container registry repository as source of versions, e.g. ghcr.io/org/app. Credentials are read from the docker config file.")
+}
+
+// addHelmFlags adds the flags reading versions from a chart repository index
+func addHelmFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&helmIndex, "helm-index", "", "Use the chart versions listed in a chart repository index, given as a file or URL, as source of versions")
+	cmd.Flags().StringVar(&helmChart, "chart", "", "Chart of the chart repository index to use the versions of, required when the index holds several charts")
+	cmd.Flags().BoolVar(&helmApp, "app-version", false, "Use the app versions of the chart instead of its chart versions")
+}
+
+// validHelm returns an error if the chart flags are used without an index
+func validHelm() error {
+	if (helmChart != "" || helmApp) && helmIndex == "" {
+		return errors.New("--chart and --app-version are only allowed when specifying a chart repository index")
+	}
+	return nil
+}
+
+// addStreamFlags adds the flags reading lists of versions from stdin and files
+func addStreamFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&readStdin, "stdin", false, "Read versions from stdin, separated by whitespace or as a JSON array. Same as passing - as version.")
+	cmd.Flags().StringArrayVar(&fromFiles, "from-file", nil, "Read versions from a file, separated by whitespace or as a JSON array. Can be repeated.")
+	cmd.Flags().StringVar(&extract, "extract", "", "Regular expression extracting the versions from each line read from stdin or files, using its first group if any, e.g. 'refs/tags/([^^]+)$'")
+}
+
+// validStream returns an error if the extractor is invalid or used without a
+// list of versions to read
+func validStream(args []string) error {
+	if extract == "" {
+		return nil
+	}
+
+	if !readsStream(args) {
+		return errors.New("--extract is only allowed when reading versions from stdin or files")
+	}
+
+	if _, err := regexp.Compile(extract); err != nil {
+		return fmt.Errorf("invalid extract expression: %w", err)
+	}
+	return nil
+}
+
+// readsStream reports whether versions are read from stdin or files
+func readsStream(args []string) bool {
+	return readStdin || len(fromFiles) > 0 || hasStdinArg(args)
+}
+
+// hasStdinArg reports whether - is passed as version to read from stdin
+func hasStdinArg(args []string) bool {
+	for _, a := range args {
+		if a == "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// scanInput calls fn with each version read from a list, as it's read
+func scanInput(r io.Reader, source string, file string, fn func(input) error) error {
+	var re *regexp.Regexp
+	if extract != "" {
+		re = regexp.MustCompile(extract)
+	}
+
+	err := stream.Scan(r, re, func(v string) error {
+		return fn(input{raw: v, source: source, file: file})
+	})
+	if err != nil {
+		if file == "" {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	return nil
+}
+
+// addModeFlags adds the flags selecting the grammar versions are parsed with
+func addModeFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&strict, "strict", false, "Only accept versions following the SemVer 2.0.0 grammar")
+	cmd.Flags().BoolVar(&loose, "loose", false, "Accept versions that can be coerced, such as v1.2 or 1.2-beta.5 (default)")
+}
+
+// validMode returns an error if both parse modes are selected
+func validMode() error {
+	if strict && loose {
+		return errors.New("only one of --strict or --loose may be specified")
+	}
+	return nil
+}
+
+// parseMode returns the selected parse mode
+func parseMode() semver.ParseMode {
+	if strict {
+		return semver.Strict
+	}
+	return semver.Loose
+}
+
+// input is a raw version value and where it came from
+type input struct {
+	raw    string
+	source string
+	// commit is the commit a git tag points to
+	commit string
+	// file is the manifest file spec a version was read from
+	file string
+}
+
+// entry is a valid version and the input it was parsed from
+type entry struct {
+	input
+	version *semver.Version
+}
+
+// rejection is an input that isn't a valid version
+type rejection struct {
+	input
+	err error
+}
+
+// inputs returns the raw version values passed in as arguments or read from
+// stdin and files, along with any tags from the git repo or the registry
+// repository, chart versions from a chart repository index and versions held
+// in manifest files
+func inputs(args []string) ([]input, error) {
+	ins := make([]input, 0, len(args))
+	err := eachInput(args, func(in input) error {
+		ins = append(ins, in)
+		return nil
+	})
+	return ins, err
+}
+
+// eachInput calls fn with each of the inputs as it's read, in the order of
+// inputs, so lists read from stdin and files are never held in memory
+func eachInput(args []string, fn func(input) error) error {
+	for _, a := range args {
+		if a == "-" {
+			continue
+		}
+		if err := fn(input{raw: a, source: sourceArg}); err != nil {
+			return err
+		}
+	}
+
+	// stdin is read once, whether it's passed as - or with --stdin
+	if readStdin || hasStdinArg(args) {
+		if err := scanInput(os.Stdin, sourceStdin, "", fn); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range fromFiles {
+		r, err := os.Open(f)
+		if err != nil {
+			return err
+		}
+
+		err = scanInput(r, sourceFromFile, f, fn)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if gdir != "" {
+		tags, err := git.ListTags(gdir, tagOptions())
+		if err != nil {
+			return err
+		}
+
+		for _, t := range tags {
+			if err := fn(input{raw: t.Name, source: sourceGit, commit: t.Commit}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if registryRepo != "" {
+		tags, err := registry.Tags(registryRepo, nil)
+		if err != nil {
+			return err
+		}
+
+		// the tags are restricted like git tags, except for reachability
+		names, err := tagOptions().Filter(tags)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range names {
+			if err := fn(input{raw: t, source: sourceRegistry}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if helmIndex != "" {
+		idx, err := helm.ReadIndex(helmIndex, nil)
+		if err != nil {
+			return err
+		}
+
+		versions, err := idx.Versions(helmChart, helmApp)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range versions {
+			if err := fn(input{raw: v, source: sourceHelm}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, f := range files {
+		spec := manifest.ParseSpec(f)
+		raw, err := manifest.Read(spec)
+		if err != nil {
+			return err
+		}
+		if err := fn(input{raw: raw, source: sourceFile, file: spec.String()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseInput returns the version of an input in the parse mode
+func parseInput(in input) (*semver.Version, error) {
+	if d := semver.Diagnose(in.raw, parseMode()); d != nil {
+		return nil, d
+	}
+	return semver.NewVersion(in.raw)
+}
+
+// collector parses inputs as they're read, keeping only what the output
+// needs: the latest valid version alone when nothing else is shown, and the
+// inputs that aren't valid versions only for structured output
+type collector struct {
+	latestOnly   bool
+	keepRejected bool
+	valid        []entry
+	rejected     []rejection
+}
+
+// newCollector returns a collector of the inputs, keeping only the latest
+// valid version when latestOnly is set
+func newCollector(latestOnly bool) *collector {
+	return &collector{
+		latestOnly:   latestOnly,
+		keepRejected: outputFormat != outputText,
+		valid:        make([]entry, 0),
+		rejected:     make([]rejection, 0),
+	}
+}
+
+// add parses an input and keeps it if the output needs it
+func (c *collector) add(in input) error {
+	v, err := parseInput(in)
+	if err != nil {
+		if c.keepRejected {
+			c.rejected = append(c.rejected, rejection{input: in, err: err})
+		}
+		return nil
+	}
+
+	e := entry{input: in, version: v}
+	if !c.latestOnly {
+		c.valid = append(c.valid, e)
+		return nil
+	}
+
+	// the last of equal versions is the latest, as when sorting
+	if len(c.valid) == 0 {
+		c.valid = append(c.valid, e)
+	} else if !v.LessThan(c.valid[0].version) {
+		c.valid[0] = e
+	}
+	return nil
+}
+
+// result returns the sorted valid versions kept along with the inputs that
+// aren't valid versions
+func (c *collector) result() ([]entry, []rejection) {
+	sort.SliceStable(c.valid, func(i, j int) bool {
+		return c.valid[i].version.LessThan(c.valid[j].version)
+	})
+	return c.valid, c.rejected
+}
+
+// collectInputs parses the inputs as they're read and returns the sorted
+// valid versions along with the inputs that aren't valid versions, see
+// collector for what is kept
+func collectInputs(args []string, latestOnly bool) ([]entry, []rejection, error) {
+	c := newCollector(latestOnly)
+	if err := eachInput(args, c.add); err != nil {
+		return nil, nil, err
+	}
+
+	valid, rejected := c.result()
+	return valid, rejected, nil
+}
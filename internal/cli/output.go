@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// output formats
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+var outputFormat string
+
+// versionOutput is the structured output of a valid version
+type versionOutput struct {
+	Raw        string   `json:"raw" yaml:"raw"`
+	Version    string   `json:"version" yaml:"version"`
+	Major      uint64   `json:"major" yaml:"major"`
+	Minor      uint64   `json:"minor" yaml:"minor"`
+	Patch      uint64   `json:"patch" yaml:"patch"`
+	Prerelease []string `json:"prerelease,omitempty" yaml:"prerelease,omitempty"`
+	Metadata   string   `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Source     string   `json:"source" yaml:"source"`
+	Tag        string   `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Commit     string   `json:"commit,omitempty" yaml:"commit,omitempty"`
+	File       string   `json:"file,omitempty" yaml:"file,omitempty"`
+}
+
+// rejectedOutput is the structured output of an input that isn't a valid version
+type rejectedOutput struct {
+	Raw    string `json:"raw" yaml:"raw"`
+	Source string `json:"source" yaml:"source"`
+	Error  string `json:"error" yaml:"error"`
+}
+
+// listOutput is the structured output of a list of versions
+type listOutput struct {
+	Versions []versionOutput  `json:"versions" yaml:"versions"`
+	Rejected []rejectedOutput `json:"rejected" yaml:"rejected"`
+}
+
+// incrementOutput is the structured output of an increment
+type incrementOutput struct {
+	Base        versionOutput    `json:"base" yaml:"base"`
+	ReleaseType string           `json:"releaseType" yaml:"releaseType"`
+	Preid       string           `json:"preid,omitempty" yaml:"preid,omitempty"`
+	Version     string           `json:"version" yaml:"version"`
+	Rejected    []rejectedOutput `json:"rejected" yaml:"rejected"`
+}
+
+// addOutputFlag adds the flag selecting the output format
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", outputText, "Output format. One of: text, json or yaml")
+}
+
+// validOutput returns an error if the output format is unknown
+func validOutput() error {
+	switch outputFormat {
+	case outputText, outputJSON, outputYAML:
+		return nil
+	}
+	return fmt.Errorf("unknown output format: %s", outputFormat)
+}
+
+// printOutput prints the text when the output format is text, and the
+// structured result otherwise
+func printOutput(text string, result interface{}) error {
+	switch outputFormat {
+	case outputJSON:
+		b, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+
+	case outputYAML:
+		b, err := yaml.Marshal(result)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(b))
+
+	default:
+		fmt.Println(text)
+	}
+	return nil
+}
+
+// newVersionOutput returns the structured output of a valid version
+func newVersionOutput(e entry) versionOutput {
+	o := versionOutput{
+		Raw:        e.raw,
+		Version:    e.version.String(),
+		Major:      e.version.Major(),
+		Minor:      e.version.Minor(),
+		Patch:      e.version.Patch(),
+		Prerelease: e.version.Prerelease(),
+		Metadata:   e.version.Metadata(),
+		Source:     e.source,
+		Commit:     e.commit,
+		File:       e.file,
+	}
+
+	if e.source == sourceGit || e.source == sourceRegistry {
+		o.Tag = tagPrefix + e.raw
+	}
+	return o
+}
+
+// newVersionOutputs returns the structured output of valid versions
+func newVersionOutputs(es []entry) []versionOutput {
+	o := make([]versionOutput, len(es))
+	for i, e := range es {
+		o[i] = newVersionOutput(e)
+	}
+	return o
+}
+
+// newRejectedOutputs returns the structured output of inputs that aren't valid versions
+func newRejectedOutputs(rs []rejection) []rejectedOutput {
+	o := make([]rejectedOutput, len(rs))
+	for i, r := range rs {
+		o[i] = rejectedOutput{
+			Raw:    r.raw,
+			Source: r.source,
+			Error:  r.err.Error(),
+		}
+	}
+	return o
+}
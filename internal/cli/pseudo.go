@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pinterb/go-semver/internal/git"
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+)
+
+var pathMajor string
+
+// pseudoOutput is the structured output of a pseudo-version
+type pseudoOutput struct {
+	Version  string `json:"version" yaml:"version"`
+	Base     string `json:"base,omitempty" yaml:"base,omitempty"`
+	Time     string `json:"time,omitempty" yaml:"time,omitempty"`
+	Revision string `json:"revision,omitempty" yaml:"revision,omitempty"`
+	Module   string `json:"module,omitempty" yaml:"module,omitempty"`
+}
+
+func newPseudo() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pseudo [REVISION | PSEUDO-VERSION]",
+		Short: "Generate or parse Go module pseudo-versions",
+		Long: `
+Print the Go module pseudo-version of a revision of a local git repo,
+such as v1.2.4-0.20220919091848-fb04ddd9f9c8, following the rules of the
+go command. The base is the highest tagged version reachable from the
+revision whose major version matches the module path in go.mod. A tagged
+revision is printed as its tag.
+
+When given a pseudo-version instead, print the version it is based on,
+or none, the commit time and the revision.
+`,
+		Example: `semver pseudo
+semver pseudo --tag-prefix tools/ main
+semver pseudo v0.0.0-20220919091848-fb04ddd9f9c8`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := handlePseudo(cmd, args); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				fmt.Fprintln(os.Stderr, errors.New("only one revision may be provided"))
+				os.Exit(2)
+			}
+			if err := applyConfig(cmd); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if err := validOutput(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().StringVarP(&gdir, "repo-dir", "r", "", "Local git repo of the module (default current working directory)")
+	cmd.Flags().StringVar(&tagPrefix, "tag-prefix", "", "Prefix of the tags of a nested module, i.e. its directory followed by a /")
+	cmd.Flags().StringVar(&pathMajor, "major", "", "Major version of the module, e.g. v2 (default from the module path in go.mod)")
+
+	addOutputFlag(cmd)
+
+	cmd.Flags().BoolP("help", "h", false, "Help for pseudo")
+	return cmd
+}
+
+func handlePseudo(cmd *cobra.Command, args []string) error {
+	rev := "HEAD"
+	if len(args) > 0 {
+		rev = args[0]
+	}
+
+	if semver.IsPseudoVersion(rev) {
+		p, err := semver.ParsePseudoVersion(rev)
+		if err != nil {
+			return err
+		}
+
+		base := p.Base
+		if base == "" {
+			base = "none"
+		}
+
+		text := fmt.Sprintf("%s %s %s", base, p.Time.Format("2006-01-02T15:04:05Z"), p.Revision)
+		return printOutput(text, pseudoOutput{
+			Version:  p.Version,
+			Base:     p.Base,
+			Time:     p.Time.Format("2006-01-02T15:04:05Z"),
+			Revision: p.Revision,
+		})
+	}
+
+	path, err := modulePath()
+	if err != nil {
+		return err
+	}
+
+	major := pathMajor
+	if major == "" {
+		if pm := semver.PathMajor(path); pm != "" {
+			major = pm[1:]
+		}
+	}
+
+	c, err := git.ResolveCommit(gdir, rev)
+	if err != nil {
+		return err
+	}
+
+	// the go command only considers canonical tags of the major version
+	opts := tagOptions()
+	opts.ReachableFrom = c.Hash
+	tags, err := git.ListTags(gdir, opts)
+	if err != nil {
+		return err
+	}
+
+	var base *semver.Version
+	var baseTag git.Tag
+	for _, t := range tags {
+		if !strings.HasPrefix(t.Name, "v") || semver.Diagnose(t.Name[1:], semver.Strict) != nil {
+			continue
+		}
+
+		v, err := semver.NewVersion(t.Name)
+		if err != nil {
+			continue
+		}
+
+		tm := fmt.Sprintf("v%d", v.Major())
+		if (major == "" && v.Major() >= 2) || (major != "" && tm != major && !(major == "v1" && v.Major() == 0)) {
+			continue
+		}
+
+		if base == nil || v.GreaterThan(base) || (v.Equal(base) && t.Commit == c.Hash) {
+			base, baseTag = v, t
+		}
+	}
+
+	o := pseudoOutput{Module: path}
+	if base != nil && baseTag.Commit == c.Hash {
+		o.Version = baseTag.Name
+		return printOutput(o.Version, o)
+	}
+
+	from := ""
+	if base != nil {
+		from = baseTag.Name
+		o.Base = from
+	}
+
+	o.Version, err = semver.NewPseudoVersion(major, from, c.Committed, c.Hash)
+	if err != nil {
+		return err
+	}
+	o.Time = c.Committed.UTC().Format("2006-01-02T15:04:05Z")
+	o.Revision = c.Hash[:12]
+	return printOutput(o.Version, o)
+}
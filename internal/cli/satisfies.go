@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+)
+
+var (
+	maxOnly bool
+	minOnly bool
+)
+
+func newSatisfies() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "satisfies RANGE [VERSION...]",
+		Short: "List the versions that satisfy a range",
+		Long: `
+List the valid versions that satisfy an npm style range, such as
+">=1.2.0 <2.0.0 || ^3.1", "~1.2" or "1.2 - 1.4". Versions can be
+passed as arguments or taken from the tags of a local git repo.
+
+Exits with a status of 1 when no version satisfies the range.
+`,
+		Example: `semver satisfies ">=1.2.0 <2.0.0 || ^3.1" 1.1.0 1.4.2 3.1.7
+semver satisfies "^1.2" -r --max`,
+		Run: func(cmd *cobra.Command, args []string) {
+			found, err := handleSatisfies(cmd, args)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if !found {
+				os.Exit(1)
+			}
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validSatisfiesArgs(cmd, args); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			return nil
+		},
+	}
+
+	path, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().StringVarP(&gdir, "repo-dir", "r", "", "Use tags from a local git repo as source of versions.")
+	cmd.Flag("repo-dir").NoOptDefVal = path
+
+	addTagFlags(cmd)
+
+	cmd.Flags().BoolVar(&maxOnly, "max", false, "Only return the highest satisfying version")
+	cmd.Flags().BoolVar(&minOnly, "min", false, "Only return the lowest satisfying version")
+
+	addModeFlags(cmd)
+
+	addOutputFlag(cmd)
+
+	cmd.Flags().BoolP("help", "h", false, "Help for satisfies")
+	return cmd
+}
+
+func validSatisfiesArgs(cmd *cobra.Command, args []string) error {
+	if err := applyConfig(cmd); err != nil {
+		return err
+	}
+
+	if err := validOutput(); err != nil {
+		return err
+	}
+
+	if err := validMode(); err != nil {
+		return err
+	}
+
+	if len(args) < 1 {
+		return errors.New("a range needs to be provided")
+	}
+
+	if len(args) < 2 && gdir == "" {
+		return errors.New("at least one version needs to be provided")
+	}
+
+	if len(args) > 1 && gdir != "" {
+		return errors.New("versions are not allowed when specifying a git repository")
+	}
+
+	if (reachFrom != "" || tagPrefix != "" || tagPattern != "") && gdir == "" {
+		return errors.New("tag filters are only allowed when specifying a git repository")
+	}
+
+	if maxOnly && minOnly {
+		return errors.New("only one of --max or --min may be specified")
+	}
+
+	return nil
+}
+
+// handleSatisfies prints the versions satisfying the range and reports
+// whether any were found
+func handleSatisfies(cmd *cobra.Command, args []string) (bool, error) {
+	rng, err := semver.NewRange(args[0])
+	if err != nil {
+		return false, err
+	}
+
+	valid, rejected, err := collectInputs(args[1:], false)
+	if err != nil {
+		return false, err
+	}
+	satisfying := make([]entry, 0, len(valid))
+	for _, e := range valid {
+		if rng.Check(e.version) {
+			satisfying = append(satisfying, e)
+		}
+	}
+
+	if len(satisfying) > 0 {
+		switch {
+		case maxOnly:
+			satisfying = satisfying[len(satisfying)-1:]
+		case minOnly:
+			satisfying = satisfying[:1]
+		}
+	}
+
+	names := make([]string, len(satisfying))
+	for i, e := range satisfying {
+		names[i] = display(e.version)
+	}
+
+	if len(satisfying) == 0 && outputFormat == outputText {
+		return false, nil
+	}
+
+	err = printOutput(strings.Join(names, " "), listOutput{
+		Versions: newVersionOutputs(satisfying),
+		Rejected: newRejectedOutputs(rejected),
+	})
+	return len(satisfying) > 0, err
+}
@@ -0,0 +1,279 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+)
+
+var (
+	schemeName   string
+	schemeFormat string
+	schemeDate   string
+)
+
+// schemeEntry is a valid version of a versioning scheme and the input it was
+// parsed from
+type schemeEntry struct {
+	input
+	version semver.Ordered
+}
+
+// schemeVersionOutput is the structured output of a valid version of a
+// versioning scheme other than semver
+type schemeVersionOutput struct {
+	Raw     string `json:"raw" yaml:"raw"`
+	Version string `json:"version" yaml:"version"`
+	Source  string `json:"source" yaml:"source"`
+	Tag     string `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Commit  string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	File    string `json:"file,omitempty" yaml:"file,omitempty"`
+}
+
+// schemeListOutput is the structured output of a list of versions of a
+// versioning scheme other than semver
+type schemeListOutput struct {
+	Scheme   string                `json:"scheme" yaml:"scheme"`
+	Format   string                `json:"format,omitempty" yaml:"format,omitempty"`
+	Versions []schemeVersionOutput `json:"versions" yaml:"versions"`
+	Rejected []rejectedOutput      `json:"rejected" yaml:"rejected"`
+}
+
+// schemeIncrementOutput is the structured output of an increment of a
+// version of a versioning scheme other than semver
+type schemeIncrementOutput struct {
+	Scheme      string              `json:"scheme" yaml:"scheme"`
+	Format      string              `json:"format,omitempty" yaml:"format,omitempty"`
+	Base        schemeVersionOutput `json:"base" yaml:"base"`
+	ReleaseType string              `json:"releaseType" yaml:"releaseType"`
+	Version     string              `json:"version" yaml:"version"`
+	Rejected    []rejectedOutput    `json:"rejected" yaml:"rejected"`
+}
+
+// addSchemeFlags adds the flags selecting the versioning scheme
+func addSchemeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&schemeName, "scheme", "", fmt.Sprintf("Versioning scheme of the versions. One of: %s (default semver)", strings.Join(semver.SchemeNames(), ", ")))
+	cmd.Flags().StringVar(&schemeFormat, "format", "", "Format of the versions of the scheme, e.g. YYYY.0M.MICRO for calver")
+	cmd.Flags().StringVar(&schemeDate, "date", "", "Date calver increments roll to, as YYYY-MM-DD (default today)")
+}
+
+// customScheme reports whether a versioning scheme other than semver is
+// selected
+func customScheme() bool {
+	return (schemeName != "" || schemeFormat != "") && !strings.EqualFold(schemeName, "semver")
+}
+
+// versionScheme returns the selected versioning scheme
+func versionScheme() (semver.Scheme, error) {
+	if !customScheme() {
+		if schemeFormat != "" {
+			return nil, errors.New("the semver scheme doesn't take a format")
+		}
+		return semver.SemVer, nil
+	}
+
+	s, err := semver.NewScheme(schemeName, schemeFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	if c, ok := s.(*semver.CalVer); ok && schemeDate != "" {
+		d, err := time.Parse("2006-01-02", schemeDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q, must be YYYY-MM-DD", schemeDate)
+		}
+		c.Date = d
+	}
+	return s, nil
+}
+
+// validScheme returns an error if the versioning scheme or the options used
+// with it aren't valid
+func validScheme() error {
+	s, err := versionScheme()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := s.(*semver.CalVer); !ok && schemeDate != "" {
+		return errors.New("a date is only allowed with the calver scheme")
+	}
+
+	if !customScheme() {
+		return nil
+	}
+
+	switch {
+	case incr == autoIncrement:
+		return fmt.Errorf("automatic increments are not supported by the %s scheme", s.Name())
+	case buildFlagsSet():
+		return fmt.Errorf("build metadata is not supported by the %s scheme", s.Name())
+	case strict:
+		return fmt.Errorf("strict parsing is not supported by the %s scheme", s.Name())
+	case keepV:
+		return fmt.Errorf("keeping the v prefix is not supported by the %s scheme", s.Name())
+	}
+	return nil
+}
+
+// schemeCollector parses inputs in a versioning scheme as they're read,
+// keeping only what the output needs like collector
+type schemeCollector struct {
+	scheme       semver.Scheme
+	latestOnly   bool
+	keepRejected bool
+	valid        []schemeEntry
+	rejected     []rejection
+}
+
+// add parses an input and keeps it if the output needs it
+func (c *schemeCollector) add(in input) error {
+	v, err := c.scheme.Parse(in.raw)
+	if err != nil {
+		if c.keepRejected {
+			c.rejected = append(c.rejected, rejection{input: in, err: err})
+		}
+		return nil
+	}
+
+	e := schemeEntry{input: in, version: v}
+	if !c.latestOnly {
+		c.valid = append(c.valid, e)
+		return nil
+	}
+
+	// the last of equal versions is the latest, as when sorting
+	if len(c.valid) == 0 {
+		c.valid = append(c.valid, e)
+	} else if v.Compare(c.valid[0].version) >= 0 {
+		c.valid[0] = e
+	}
+	return nil
+}
+
+// result returns the sorted valid versions kept along with the inputs that
+// aren't valid versions
+func (c *schemeCollector) result() ([]schemeEntry, []rejection) {
+	sort.SliceStable(c.valid, func(i, j int) bool {
+		return c.valid[i].version.Compare(c.valid[j].version) < 0
+	})
+	return c.valid, c.rejected
+}
+
+// handleSchemeVersions lists or increments the versions of a versioning
+// scheme other than semver
+func handleSchemeVersions(s semver.Scheme, args []string) error {
+	// parse the versions as they're read, keeping only the latest when
+	// nothing else is output
+	c := &schemeCollector{
+		scheme:       s,
+		latestOnly:   latestOnly || incr != "",
+		keepRejected: outputFormat != outputText,
+		valid:        make([]schemeEntry, 0),
+		rejected:     make([]rejection, 0),
+	}
+	if defv != "" {
+		if err := c.add(input{raw: defv, source: sourceDefault}); err != nil {
+			return err
+		}
+	}
+
+	if err := eachInput(args, c.add); err != nil {
+		return err
+	}
+
+	valid, rejected := c.result()
+
+	if len(valid) == 0 {
+		if outputFormat != outputText {
+			return printOutput("", newSchemeListOutput(s, valid, rejected))
+		}
+		return nil
+	}
+
+	latest := valid[len(valid)-1]
+	if incr == "" {
+		if latestOnly {
+			valid = valid[len(valid)-1:]
+		}
+
+		names := make([]string, len(valid))
+		for i, e := range valid {
+			names[i] = schemeDisplay(e.version)
+		}
+		return printOutput(strings.Join(names, " "), newSchemeListOutput(s, valid, rejected))
+	}
+
+	rt, err := semver.ToReleaseType(incr)
+	if err != nil {
+		return err
+	}
+
+	nv, err := s.Increment(latest.version.String(), rt, preid)
+	if err != nil {
+		return err
+	}
+	nv = tagOptions().TagName(nv)
+
+	if createTag {
+		if err := tagVersion(nv, schemeDisplay(latest.version)); err != nil {
+			return err
+		}
+	}
+
+	return printOutput(nv, schemeIncrementOutput{
+		Scheme:      s.Name(),
+		Format:      schemeFormat,
+		Base:        newSchemeVersionOutput(latest),
+		ReleaseType: rt.String(),
+		Version:     nv,
+		Rejected:    newRejectedOutputs(rejected),
+	})
+}
+
+// schemeDisplay returns the spelling of a version of a versioning scheme to
+// output
+func schemeDisplay(v semver.Ordered) string {
+	s := v.String()
+	if rawOutput {
+		s = v.Original()
+	}
+	return tagOptions().TagName(s)
+}
+
+// newSchemeVersionOutput returns the structured output of a valid version of
+// a versioning scheme
+func newSchemeVersionOutput(e schemeEntry) schemeVersionOutput {
+	o := schemeVersionOutput{
+		Raw:     e.raw,
+		Version: e.version.String(),
+		Source:  e.source,
+		Commit:  e.commit,
+		File:    e.file,
+	}
+
+	if e.source == sourceGit || e.source == sourceRegistry {
+		o.Tag = tagPrefix + e.raw
+	}
+	return o
+}
+
+// newSchemeListOutput returns the structured output of a list of versions of
+// a versioning scheme
+func newSchemeListOutput(s semver.Scheme, es []schemeEntry, rs []rejection) schemeListOutput {
+	o := schemeListOutput{
+		Scheme:   s.Name(),
+		Format:   schemeFormat,
+		Versions: make([]schemeVersionOutput, len(es)),
+		Rejected: newRejectedOutputs(rs),
+	}
+	for i, e := range es {
+		o.Versions[i] = newSchemeVersionOutput(e)
+	}
+	return o
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/pinterb/go-semver/pkg/semver"
+	"github.com/spf13/cobra"
+)
+
+// diagnosticOutput is the structured output of an input that isn't a valid version
+type diagnosticOutput struct {
+	Index  int    `json:"index" yaml:"index"`
+	Raw    string `json:"raw" yaml:"raw"`
+	Source string `json:"source" yaml:"source"`
+	Offset int    `json:"offset" yaml:"offset"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// validateOutput is the structured output of a validation
+type validateOutput struct {
+	Mode     string             `json:"mode" yaml:"mode"`
+	Versions []versionOutput    `json:"versions" yaml:"versions"`
+	Invalid  []diagnosticOutput `json:"invalid" yaml:"invalid"`
+}
+
+func newValidate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [VERSION...]",
+		Short: "Report the versions that aren't valid",
+		Long: `
+Check raw versions, passed as arguments or taken from the tags of a
+local git repo, and report why each invalid one was rejected, e.g. a
+leading zero, too many components or an invalid character.
+
+By default versions that can be coerced into a semantic version, such
+as v1.2, are valid. Use --strict to only accept the SemVer 2.0.0
+grammar, which makes validate usable as a pre-push tag lint.
+
+Exits with a status of 1 when any version isn't valid.
+`,
+		Example: `semver validate 1.2.3 1.2.beta 01.2.3
+semver validate -r --strict
+npm view pkg versions --json | semver validate -`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ok, err := handleValidate(cmd, args)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if !ok {
+				os.Exit(1)
+			}
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validValidateArgs(cmd, args); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			return nil
+		},
+	}
+
+	path, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().StringVarP(&gdir, "repo-dir", "r", "", "Use tags from a local git repo as source of versions.")
+	cmd.Flag("repo-dir").NoOptDefVal = path
+
+	addTagFlags(cmd)
+
+	addStreamFlags(cmd)
+
+	addModeFlags(cmd)
+
+	addOutputFlag(cmd)
+
+	cmd.Flags().BoolP("help", "h", false, "Help for validate")
+	return cmd
+}
+
+func validValidateArgs(cmd *cobra.Command, args []string) error {
+	if err := applyConfig(cmd); err != nil {
+		return err
+	}
+
+	if err := validOutput(); err != nil {
+		return err
+	}
+
+	if err := validMode(); err != nil {
+		return err
+	}
+
+	if len(args) < 1 && gdir == "" && !readsStream(args) {
+		return errors.New("at least one version needs to be provided")
+	}
+
+	if len(args) > 0 && gdir != "" {
+		return errors.New("versions are not allowed when specifying a git repository")
+	}
+
+	if (reachFrom != "" || tagPrefix != "" || tagPattern != "") && gdir == "" {
+		return errors.New("tag filters are only allowed when specifying a git repository")
+	}
+
+	if err := validStream(args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleValidate prints a diagnostic for each invalid version and reports
+// whether all versions are valid
+func handleValidate(cmd *cobra.Command, args []string) (bool, error) {
+	mode := parseMode()
+
+	// each diagnostic is printed as its version is read, only structured
+	// output keeps the versions
+	structured := outputFormat != outputText
+	valid := make([]entry, 0)
+	invalid := make([]diagnosticOutput, 0)
+	count, n := 0, 0
+	err := eachInput(args, func(in input) error {
+		i := n
+		n++
+
+		if d := semver.Diagnose(in.raw, mode); d != nil {
+			count++
+			if !structured {
+				fmt.Println(d.Error())
+				return nil
+			}
+			invalid = append(invalid, diagnosticOutput{
+				Index:  i,
+				Raw:    in.raw,
+				Source: in.source,
+				Offset: d.Offset,
+				Reason: d.Reason,
+			})
+			return nil
+		}
+
+		if !structured {
+			return nil
+		}
+		v, err := semver.NewVersion(in.raw)
+		if err != nil {
+			return err
+		}
+		valid = append(valid, entry{input: in, version: v})
+		return nil
+	})
+	if err != nil || !structured {
+		return count == 0, err
+	}
+
+	err = printOutput("", validateOutput{
+		Mode:     mode.String(),
+		Versions: newVersionOutputs(valid),
+		Invalid:  invalid,
+	})
+	return count == 0, err
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/pinterb/go-semver/internal/git"
+	"github.com/pinterb/go-semver/pkg/semver"
+	"gopkg.in/yaml.v2"
+)
+
+// FileName is the name of the project configuration file found at the root
+// of a git repository
+const FileName = ".semver.yaml"
+
+// Config is the project configuration. Its settings are defaults for the
+// flags of the command line tool.
+type Config struct {
+	// Path is the path of the file the configuration was loaded from
+	Path string `yaml:"-"`
+	// TagPrefix is the prefix of the tags of the project, e.g. services/api/
+	TagPrefix string `yaml:"tagPrefix,omitempty"`
+	// Default is the version to use when no valid versions are found
+	Default string `yaml:"default,omitempty"`
+	// Preid is the identifier of prerelease increments, e.g. rc
+	Preid string `yaml:"preid,omitempty"`
+	// Strict only accepts versions following the SemVer 2.0.0 grammar
+	Strict bool `yaml:"strict,omitempty"`
+	// Files are the manifest files to bump, given as path[:field] relative to
+	// the configuration file
+	Files []string `yaml:"files,omitempty"`
+	// ReleaseTypes maps commit types to the release type they require, one of
+	// major, minor or patch, on top of the defaults
+	ReleaseTypes map[string]string `yaml:"releaseTypes,omitempty"`
+	// TagMessage is the template of the message of created tags. The
+	// template is given the Version and the Previous version.
+	TagMessage string `yaml:"tagMessage,omitempty"`
+	// DescribeTemplate is the template of the prerelease identifiers and
+	// build metadata of development versions
+	DescribeTemplate string `yaml:"describeTemplate,omitempty"`
+	// Scheme is the versioning scheme of the project, e.g. calver
+	Scheme string `yaml:"scheme,omitempty"`
+	// Format is the format of the versions of the scheme, e.g. YYYY.0M.MICRO
+	Format string `yaml:"format,omitempty"`
+}
+
+// Find returns the path of the configuration file at the root of the git
+// repository containing a known location, or an empty string if there is none
+func Find(path string) (string, error) {
+	root, err := git.Root(path)
+	if errors.Is(err, git.ErrRepositoryNotExists) || errors.Is(err, git.ErrIsBareRepository) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	p := filepath.Join(root, FileName)
+	if _, err := os.Stat(p); err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return p, nil
+}
+
+// Load reads and checks a configuration file
+func Load(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Config{Path: path}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := c.check(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Discover loads the configuration file of the git repository containing a
+// known location. An empty configuration is returned when there is none.
+func Discover(path string) (*Config, error) {
+	p, err := Find(path)
+	if err != nil || p == "" {
+		return &Config{}, err
+	}
+	return Load(p)
+}
+
+// check returns an error if a setting isn't valid
+func (c *Config) check() error {
+	s := semver.SemVer
+	if c.Scheme != "" || c.Format != "" {
+		var err error
+		if s, err = semver.NewScheme(c.Scheme, c.Format); err != nil {
+			return fmt.Errorf("scheme: %w", err)
+		}
+	}
+
+	if c.Default != "" {
+		if _, err := s.Parse(c.Default); err != nil {
+			return fmt.Errorf("default: %w", err)
+		}
+	}
+
+	if _, err := c.Types(); err != nil {
+		return err
+	}
+
+	if c.TagMessage != "" {
+		if _, err := template.New("tagMessage").Parse(c.TagMessage); err != nil {
+			return fmt.Errorf("tagMessage: %w", err)
+		}
+	}
+
+	if c.DescribeTemplate != "" {
+		if _, err := template.New("describeTemplate").Parse(c.DescribeTemplate); err != nil {
+			return fmt.Errorf("describeTemplate: %w", err)
+		}
+	}
+	return nil
+}
+
+// Types returns the configured commit types mapped to their release type
+func (c *Config) Types() (map[string]semver.ReleaseType, error) {
+	types := make(map[string]semver.ReleaseType, len(c.ReleaseTypes))
+	for ct, s := range c.ReleaseTypes {
+		rt, err := semver.ToReleaseType(s)
+		if err != nil || rt > semver.Patch {
+			return nil, fmt.Errorf("releaseTypes: %s must be one of major, minor or patch, not %q", ct, s)
+		}
+		types[ct] = rt
+	}
+	return types, nil
+}
+
+// Dir returns the directory relative paths of the configuration are resolved
+// against
+func (c *Config) Dir() string {
+	if c.Path == "" {
+		return ""
+	}
+	return filepath.Dir(c.Path)
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pinterb/go-semver/pkg/semver"
+	"gopkg.in/src-d/go-git.v4"
+)
+
+// newRepo initializes a git repository in a temporary directory, with a
+// configuration file when content isn't empty
+func newRepo(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if _, err := git.PlainInit(dir, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if content != "" {
+		if err := ioutil.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestDiscover(t *testing.T) {
+	dir := newRepo(t, `tagPrefix: services/api/
+default: 0.0.0
+preid: rc
+strict: true
+files:
+  - package.json
+  - charts/api/Chart.yaml:appVersion
+releaseTypes:
+  docs: minor
+tagMessage: "Release {{.Version}}"
+`)
+	sub := filepath.Join(dir, "services", "api")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Discover(sub)
+	if err != nil {
+		t.Fatalf("error discovering config from %s: %s", sub, err)
+	}
+
+	if c.Path != filepath.Join(dir, FileName) || c.Dir() != dir {
+		t.Fatalf("expected config to be found at the repository root %s, but got %s", dir, c.Path)
+	}
+
+	if c.TagPrefix != "services/api/" || c.Default != "0.0.0" || c.Preid != "rc" || !c.Strict {
+		t.Fatalf("unexpected settings: %+v", c)
+	}
+
+	if len(c.Files) != 2 || c.Files[1] != "charts/api/Chart.yaml:appVersion" {
+		t.Fatalf("expected 2 files, but got %v", c.Files)
+	}
+
+	types, err := c.Types()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(types) != 1 || types["docs"] != semver.Minor {
+		t.Fatalf("expected docs commits to require a minor release, but got %v", types)
+	}
+}
+
+func TestDiscoverNone(t *testing.T) {
+	for _, dir := range []string{newRepo(t, ""), os.TempDir()} {
+		c, err := Discover(dir)
+		if err != nil {
+			t.Fatalf("error discovering config from %s: %s", dir, err)
+		}
+
+		if c.Path != "" || c.Dir() != "" {
+			t.Fatalf("expected no config to be found from %s, but got %s", dir, c.Path)
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		content string
+		err     string
+	}{
+		{"tag-prefix: v\n", "field tag-prefix not found"},
+		{"default: 1.x\n", "default:"},
+		{"releaseTypes:\n  docs: prerelease\n", "docs must be one of major, minor or patch"},
+		{"tagMessage: \"{{.Version\"\n", "tagMessage:"},
+		{"scheme: romver\n", "unknown version scheme"},
+		{"scheme: calver\nformat: YYYY.0M.MICRO\ndefault: 1.2.3\n", "default:"},
+	}
+
+	for _, tc := range tests {
+		dir := newRepo(t, tc.content)
+		_, err := Load(filepath.Join(dir, FileName))
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Fatalf("expected config %q to fail with %q, but got: %v", tc.content, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package conventional
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrNotConventional is returned when a commit message doesn't follow the Conventional Commits specification
+	ErrNotConventional = errors.New("commit message is not a conventional commit")
+
+	headerRegex = regexp.MustCompile(`^(\w[\w-]*)(?:\(([^()]*)\))?(!)?: (.+)$`)
+	footerRegex = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[\w-]+)(?:: | #)(.*)$`)
+)
+
+// Footer is a trailer of a commit message, e.g. "Refs: #123"
+type Footer struct {
+	Token string
+	Value string
+}
+
+// Commit is a parsed Conventional Commits message. See
+// https://www.conventionalcommits.org/en/v1.0.0/
+type Commit struct {
+	// Hash identifies the commit. It is not part of the message and is left
+	// for callers to set
+	Hash string
+	// Header is the first line of the commit message
+	Header      string
+	Type        string
+	Scope       string
+	Description string
+	Body        string
+	Footers     []Footer
+	// Breaking is true when the header has a "!" or a BREAKING CHANGE footer is present
+	Breaking bool
+	// BreakingNote is the value of the BREAKING CHANGE footer, if any
+	BreakingNote string
+}
+
+// Parse parses a commit message according to the Conventional Commits
+// specification
+func Parse(msg string) (*Commit, error) {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(msg, "\r\n", "\n")), "\n")
+	m := headerRegex.FindStringSubmatch(strings.TrimSpace(lines[0]))
+	if m == nil {
+		return nil, ErrNotConventional
+	}
+
+	c := &Commit{
+		Header:      strings.TrimSpace(lines[0]),
+		Type:        strings.ToLower(m[1]),
+		Scope:       m[2],
+		Breaking:    m[3] == "!",
+		Description: strings.TrimSpace(m[4]),
+	}
+
+	// the footers are the trailing lines following the last blank line, as
+	// long as the first of them looks like a footer
+	rest := lines[1:]
+	start := len(rest)
+	for i := len(rest) - 1; i >= 0; i-- {
+		if strings.TrimSpace(rest[i]) == "" {
+			break
+		}
+		start = i
+	}
+	if start < len(rest) && !footerRegex.MatchString(rest[start]) {
+		start = len(rest)
+	}
+
+	for _, l := range rest[start:] {
+		if fm := footerRegex.FindStringSubmatch(l); fm != nil {
+			c.Footers = append(c.Footers, Footer{Token: fm[1], Value: strings.TrimSpace(fm[2])})
+			continue
+		}
+
+		// a footer value may continue on the following lines
+		if n := len(c.Footers); n > 0 {
+			c.Footers[n-1].Value += "\n" + l
+		}
+	}
+
+	for _, f := range c.Footers {
+		if f.Token == "BREAKING CHANGE" || f.Token == "BREAKING-CHANGE" {
+			c.Breaking = true
+			c.BreakingNote = f.Value
+		}
+	}
+
+	c.Body = strings.TrimSpace(strings.Join(rest[:start], "\n"))
+	return c, nil
+}
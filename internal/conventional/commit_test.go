@@ -0,0 +1,59 @@
+package conventional
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		msg         string
+		typ         string
+		scope       string
+		description string
+		body        string
+		breaking    bool
+		footers     int
+		err         bool
+	}{
+		{"feat: add a flag", "feat", "", "add a flag", "", false, 0, false},
+		{"fix(git): handle annotated tags\n", "fix", "git", "handle annotated tags", "", false, 0, false},
+		{"feat(api)!: drop v1 endpoints", "feat", "api", "drop v1 endpoints", "", true, 0, false},
+		{"Feat: mixed case type", "feat", "", "mixed case type", "", false, 0, false},
+		{"refactor: move code\n\nSome body text\nspanning lines.\n\nBREAKING CHANGE: the API moved\nRefs: #123", "refactor", "", "move code", "Some body text\nspanning lines.", true, 2, false},
+		{"fix: typo\n\nReviewed-by: Z\nRefs #133", "fix", "", "typo", "", false, 2, false},
+		{"chore: release\n\nNot a footer: just a sentence in the body", "chore", "", "release", "Not a footer: just a sentence in the body", false, 0, false},
+		{"Merge branch 'main'", "", "", "", "", false, 0, true},
+		{"feat:missing space", "", "", "", "", false, 0, true},
+		{"", "", "", "", "", false, 0, true},
+	}
+
+	for _, tc := range tests {
+		c, err := Parse(tc.msg)
+		if tc.err {
+			if err != ErrNotConventional {
+				t.Fatalf("expected error for message %q, but got %v", tc.msg, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("error for message %q: %s", tc.msg, err)
+		}
+
+		if c.Type != tc.typ || c.Scope != tc.scope || c.Description != tc.description {
+			t.Fatalf("expected message %q to parse to %s(%s): %s, but got %s(%s): %s", tc.msg, tc.typ, tc.scope, tc.description, c.Type, c.Scope, c.Description)
+		}
+
+		if c.Body != tc.body {
+			t.Fatalf("expected message %q to have body %q, but got %q", tc.msg, tc.body, c.Body)
+		}
+
+		if c.Breaking != tc.breaking {
+			t.Fatalf("expected message %q breaking to be %t", tc.msg, tc.breaking)
+		}
+
+		if len(c.Footers) != tc.footers {
+			t.Fatalf("expected message %q to have %d footers, but got %d", tc.msg, tc.footers, len(c.Footers))
+		}
+	}
+}
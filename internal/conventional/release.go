@@ -0,0 +1,69 @@
+package conventional
+
+import (
+	"github.com/pinterb/go-semver/pkg/semver"
+)
+
+// DefaultReleaseTypes maps commit types to the release type they require.
+// Breaking changes always require a major release.
+var DefaultReleaseTypes = map[string]semver.ReleaseType{
+	"feat": semver.Minor,
+	"fix":  semver.Patch,
+	"perf": semver.Patch,
+}
+
+// Analysis is the release type required by a set of commits
+type Analysis struct {
+	// ReleaseType is the release type to increment the current version by
+	ReleaseType semver.ReleaseType
+	// Commits are the commits that required the release type. When no
+	// commit matched a known type, these are all of the commits
+	Commits []*Commit
+}
+
+// Analyze picks the release type required by a set of commit messages
+// following the Conventional Commits specification. Commits with types
+// missing from the types map, or messages that aren't conventional commits,
+// require a patch release. When the current version has a major version of
+// zero, breaking changes require a minor release instead of a major release.
+// DefaultReleaseTypes is used when types is nil.
+func Analyze(current string, commits []*Commit, types map[string]semver.ReleaseType) (*Analysis, error) {
+	v, err := semver.NewVersion(current)
+	if err != nil {
+		return nil, err
+	}
+
+	if types == nil {
+		types = DefaultReleaseTypes
+	}
+
+	a := &Analysis{ReleaseType: semver.Patch}
+	matched := false
+	for _, c := range commits {
+		rt, ok := types[c.Type]
+		if c.Breaking {
+			rt, ok = semver.Major, true
+			if v.Major() == 0 {
+				rt = semver.Minor
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		// lower release types are more significant
+		switch {
+		case !matched || rt < a.ReleaseType:
+			matched = true
+			a.ReleaseType = rt
+			a.Commits = []*Commit{c}
+		case rt == a.ReleaseType:
+			a.Commits = append(a.Commits, c)
+		}
+	}
+
+	if !matched {
+		a.Commits = commits
+	}
+	return a, nil
+}
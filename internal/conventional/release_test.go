@@ -0,0 +1,61 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/pinterb/go-semver/pkg/semver"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		current  string
+		msgs     []string
+		expected semver.ReleaseType
+		drivers  int
+	}{
+		{"1.2.3", []string{"fix: a", "docs: b"}, semver.Patch, 1},
+		{"1.2.3", []string{"fix: a", "feat: b", "feat(x): c"}, semver.Minor, 2},
+		{"1.2.3", []string{"fix: a", "feat!: b"}, semver.Major, 1},
+		{"1.2.3", []string{"fix: a\n\nBREAKING CHANGE: gone"}, semver.Major, 1},
+		{"0.4.1", []string{"feat: a", "fix!: b"}, semver.Minor, 2},
+		{"0.4.1", []string{"fix: a"}, semver.Patch, 1},
+		{"1.2.3", []string{"docs: a", "chore: b"}, semver.Patch, 2},
+	}
+
+	for _, tc := range tests {
+		commits := make([]*Commit, 0)
+		for _, m := range tc.msgs {
+			c, err := Parse(m)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			commits = append(commits, c)
+		}
+
+		a, err := Analyze(tc.current, commits, nil)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if a.ReleaseType != tc.expected {
+			t.Fatalf("expected %v from %s to require %s, but got %s", tc.msgs, tc.current, tc.expected, a.ReleaseType)
+		}
+
+		if len(a.Commits) != tc.drivers {
+			t.Fatalf("expected %v from %s to have %d driving commits, but got %d", tc.msgs, tc.current, tc.drivers, len(a.Commits))
+		}
+	}
+
+	types := map[string]semver.ReleaseType{"docs": semver.Minor}
+	a, err := Analyze("1.0.0", []*Commit{{Type: "docs"}, {Type: "feat"}}, types)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if a.ReleaseType != semver.Minor || len(a.Commits) != 1 {
+		t.Fatalf("expected custom types to require a minor release from one commit, but got %s from %d", a.ReleaseType, len(a.Commits))
+	}
+
+	if _, err := Analyze("foo", nil, nil); err == nil {
+		t.Fatal("expected error for invalid version")
+	}
+}
@@ -0,0 +1,279 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// Commit is a commit in a git repository
+type Commit struct {
+	Hash    string
+	Message string
+	Author  string
+	// When is the author time
+	When time.Time
+	// Committed is the committer time
+	Committed time.Time
+}
+
+// Commits returns the commits reachable from the until revision but not from
+// the since revision, newest first, from a git repository at a known
+// location. All commits are returned when since is empty and HEAD is used
+// when until is empty.
+func Commits(path string, since string, until string) ([]Commit, error) {
+	r, err := open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return commits(r, since, until)
+}
+
+// ResolveCommit returns the commit a revision points to in a git repository at
+// a known location
+func ResolveCommit(path string, rev string) (Commit, error) {
+	r, err := open(path)
+	if err != nil {
+		return Commit{}, err
+	}
+
+	h, err := resolve(r, rev)
+	if err != nil {
+		return Commit{}, err
+	}
+
+	c, err := r.CommitObject(h)
+	if err != nil {
+		return Commit{}, err
+	}
+	return newCommit(c), nil
+}
+
+// newCommit converts a commit object to a Commit
+func newCommit(c *object.Commit) Commit {
+	return Commit{
+		Hash:      c.Hash.String(),
+		Message:   c.Message,
+		Author:    c.Author.Name,
+		When:      c.Author.When,
+		Committed: c.Committer.When,
+	}
+}
+
+// resolve returns the commit hash a revision points to
+func resolve(r *git.Repository, rev string) (plumbing.Hash, error) {
+	if rev == "" {
+		rev = "HEAD"
+	}
+
+	h, err := r.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("unable to resolve %s: %w", rev, err)
+	}
+	return *h, nil
+}
+
+// ancestors returns the set of commits reachable from a commit, including itself
+func ancestors(r *git.Repository, h plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	seen := make(map[plumbing.Hash]bool)
+	c, err := r.CommitObject(h)
+	if err != nil {
+		return nil, err
+	}
+
+	err = object.NewCommitPreorderIter(c, nil, nil).ForEach(func(c *object.Commit) error {
+		seen[c.Hash] = true
+		return nil
+	})
+	return seen, err
+}
+
+func commits(r *git.Repository, since string, until string) ([]Commit, error) {
+	to, err := resolve(r, until)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude := make(map[plumbing.Hash]bool)
+	if since != "" {
+		from, err := resolve(r, since)
+		if err != nil {
+			return nil, err
+		}
+
+		exclude, err = ancestors(r, from)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	iter, err := r.Log(&git.LogOptions{From: to, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, err
+	}
+
+	cs := make([]Commit, 0)
+	err = iter.ForEach(func(c *object.Commit) error {
+		if exclude[c.Hash] {
+			return nil
+		}
+
+		cs = append(cs, newCommit(c))
+		return nil
+	})
+	return cs, err
+}
+
+// Ranges returns, for each of the revisions, the commits reachable from it
+// but not from the revision before it, newest first, from a git repository
+// at a known location. The revisions are usually tags, oldest first, so each
+// range holds the commits of a release. The history is walked once, however
+// many revisions there are.
+func Ranges(path string, revs []string) ([][]Commit, error) {
+	r, err := open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	heads := make([]plumbing.Hash, len(revs))
+	for i, rev := range revs {
+		if heads[i], err = resolve(r, rev); err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := topoOrder(r, heads)
+	if err != nil {
+		return nil, err
+	}
+
+	// reach holds the revisions each commit is reachable from, as bits
+	words := (len(revs) + 63) / 64
+	reach := make(map[plumbing.Hash][]uint64, len(order))
+	for _, c := range order {
+		reach[c.Hash] = make([]uint64, words)
+	}
+	for i, h := range heads {
+		reach[h][i/64] |= 1 << uint(i%64)
+	}
+
+	// children come before their parents, so a commit is reached from
+	// every revision its children are once it's visited
+	for _, c := range order {
+		for _, p := range c.ParentHashes {
+			for w, bits := range reach[c.Hash] {
+				reach[p][w] |= bits
+			}
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].Committer.When.After(order[j].Committer.When)
+	})
+
+	ranges := make([][]Commit, len(revs))
+	for i := range ranges {
+		ranges[i] = make([]Commit, 0)
+	}
+	for _, c := range order {
+		bits := reach[c.Hash]
+		for i := range revs {
+			if !hasBit(bits, i) || (i > 0 && hasBit(bits, i-1)) {
+				continue
+			}
+			ranges[i] = append(ranges[i], newCommit(c))
+		}
+	}
+	return ranges, nil
+}
+
+// hasBit reports whether the bit at an index is set
+func hasBit(bits []uint64, i int) bool {
+	return bits[i/64]&(1<<uint(i%64)) != 0
+}
+
+// topoOrder returns the commits reachable from the heads, each commit before
+// its parents
+func topoOrder(r *git.Repository, heads []plumbing.Hash) ([]*object.Commit, error) {
+	type frame struct {
+		commit *object.Commit
+		next   int
+	}
+
+	seen := make(map[plumbing.Hash]bool)
+	post := make([]*object.Commit, 0)
+	for _, h := range heads {
+		if seen[h] {
+			continue
+		}
+		seen[h] = true
+
+		c, err := r.CommitObject(h)
+		if err != nil {
+			return nil, err
+		}
+
+		// depth first, keeping commits after all of their parents
+		stack := []*frame{{commit: c}}
+		for len(stack) > 0 {
+			f := stack[len(stack)-1]
+			if f.next == len(f.commit.ParentHashes) {
+				post = append(post, f.commit)
+				stack = stack[:len(stack)-1]
+				continue
+			}
+
+			p := f.commit.ParentHashes[f.next]
+			f.next++
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+
+			pc, err := r.CommitObject(p)
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, &frame{commit: pc})
+		}
+	}
+
+	for i, j := 0, len(post)-1; i < j; i, j = i+1, j-1 {
+		post[i], post[j] = post[j], post[i]
+	}
+	return post, nil
+}
+
+// Dirty reports whether the working tree of a git repository at a known
+// location has uncommitted changes to tracked files
+func Dirty(path string) (bool, error) {
+	r, err := open(path)
+	if err != nil {
+		return false, err
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return false, err
+	}
+
+	st, err := w.Status()
+	if err != nil {
+		return false, err
+	}
+
+	for _, fs := range st {
+		if fs.Staging == git.Untracked && fs.Worktree == git.Untracked {
+			continue
+		}
+		if fs.Staging != git.Unmodified || fs.Worktree != git.Unmodified {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,209 @@
+package git
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// TestCommits verifies the commits between revisions are returned newest first
+func TestCommits(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.commit("feat: first")
+	second := tr.commit("fix: second")
+	tr.tag("v0.1.0", second, "Release v0.1.0")
+	tr.commit("feat: third")
+	tr.commit("chore: fourth")
+
+	tests := []struct {
+		since    string
+		until    string
+		expected []string
+	}{
+		{"", "", []string{"chore: fourth", "feat: third", "fix: second", "feat: first"}},
+		{"v0.1.0", "", []string{"chore: fourth", "feat: third"}},
+		{"v0.1.0", "HEAD~1", []string{"feat: third"}},
+		{"HEAD", "", []string{}},
+	}
+
+	for _, tc := range tests {
+		cs, err := Commits(tr.dir, tc.since, tc.until)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if len(cs) != len(tc.expected) {
+			t.Fatalf("expected %d commits since '%s', found %d", len(tc.expected), tc.since, len(cs))
+		}
+
+		for i, c := range cs {
+			if c.Message != tc.expected[i] {
+				t.Fatalf("expected commit message '%s', found '%s'", tc.expected[i], c.Message)
+			}
+		}
+	}
+
+	if _, err := Commits(tr.dir, "v9.9.9", ""); err == nil {
+		t.Fatal("expected error for unknown revision")
+	}
+}
+
+// TestRanges verifies the commits of each revision since the one before it
+// are returned newest first
+func TestRanges(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.commit("feat: first")
+	second := tr.commit("fix: second")
+	tr.tag("v0.1.0", second, "Release v0.1.0")
+	tr.commit("feat: third")
+	fourth := tr.commit("fix: fourth")
+	tr.tag("v0.2.0", fourth, "")
+	tr.commit("chore: fifth")
+
+	tests := []struct {
+		revs     []string
+		expected [][]string
+	}{
+		{
+			[]string{"v0.1.0", "v0.2.0", "HEAD"},
+			[][]string{{"fix: second", "feat: first"}, {"fix: fourth", "feat: third"}, {"chore: fifth"}},
+		},
+		{
+			[]string{"v0.2.0", "HEAD~1"},
+			[][]string{{"fix: fourth", "feat: third", "fix: second", "feat: first"}, {}},
+		},
+		{
+			[]string{"HEAD"},
+			[][]string{{"chore: fifth", "fix: fourth", "feat: third", "fix: second", "feat: first"}},
+		},
+	}
+
+	for _, tc := range tests {
+		ranges, err := Ranges(tr.dir, tc.revs)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if len(ranges) != len(tc.expected) {
+			t.Fatalf("expected %d ranges for %v, found %d", len(tc.expected), tc.revs, len(ranges))
+		}
+
+		for i, cs := range ranges {
+			if len(cs) != len(tc.expected[i]) {
+				t.Fatalf("expected %d commits until '%s', found %d", len(tc.expected[i]), tc.revs[i], len(cs))
+			}
+
+			for j, c := range cs {
+				if c.Message != tc.expected[i][j] {
+					t.Fatalf("expected commit message '%s', found '%s'", tc.expected[i][j], c.Message)
+				}
+			}
+		}
+	}
+
+	if _, err := Ranges(tr.dir, []string{"v9.9.9"}); err == nil {
+		t.Fatal("expected error for unknown revision")
+	}
+}
+
+// TestRangesMerge verifies commits of a merged branch are only in the range
+// of the first revision reaching them
+func TestRangesMerge(t *testing.T) {
+	tr := newTestRepo(t)
+	first := tr.commit("first")
+	tr.tag("v1.0.0", first, "")
+	tr.checkout("feature", first)
+	feature := tr.commit("feature")
+	tr.checkout("main", first)
+	main := tr.commit("main")
+
+	w, err := tr.repo.Worktree()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	tr.when = tr.when.Add(time.Minute)
+	_, err = w.Commit("merge", &git.CommitOptions{
+		Author:  &object.Signature{Name: "Test", Email: "test@example.com", When: tr.when},
+		Parents: []plumbing.Hash{main, feature},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	ranges, err := Ranges(tr.dir, []string{"v1.0.0", feature.String(), "HEAD"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := [][]string{{"first"}, {"feature"}, {"merge", "main"}}
+	for i, cs := range ranges {
+		if len(cs) != len(expected[i]) {
+			t.Fatalf("expected %d commits in range %d, found %d", len(expected[i]), i, len(cs))
+		}
+
+		for j, c := range cs {
+			if c.Message != expected[i][j] {
+				t.Fatalf("expected commit message '%s', found '%s'", expected[i][j], c.Message)
+			}
+		}
+	}
+}
+
+// TestResolveCommit verifies revisions, including annotated tags, resolve to their commit
+func TestResolveCommit(t *testing.T) {
+	tr := newTestRepo(t)
+	first := tr.commit("first")
+	tr.tag("v0.1.0", first, "Release v0.1.0")
+	tr.commit("second")
+
+	for _, rev := range []string{"v0.1.0", "refs/tags/v0.1.0", "HEAD~1", first.String()} {
+		c, err := ResolveCommit(tr.dir, rev)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if c.Hash != first.String() {
+			t.Fatalf("expected revision '%s' to resolve to %s, found %s", rev, first, c.Hash)
+		}
+
+		if c.Committed.IsZero() {
+			t.Fatalf("expected revision '%s' to have a commit time", rev)
+		}
+	}
+}
+
+// TestDirty verifies uncommitted changes to tracked files are detected
+func TestDirty(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.commit("feat: initial")
+
+	dirty, err := Dirty(tr.dir)
+	if err != nil {
+		t.Fatalf("error checking worktree: %s", err)
+	}
+
+	if dirty {
+		t.Fatal("expected a clean worktree")
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(tr.dir, "untracked.txt"), []byte("new"), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if dirty, _ := Dirty(tr.dir); dirty {
+		t.Fatal("expected untracked files to leave the worktree clean")
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(tr.dir, "file.txt"), []byte("changed"), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if dirty, _ := Dirty(tr.dir); !dirty {
+		t.Fatal("expected a modified tracked file to make the worktree dirty")
+	}
+}
@@ -0,0 +1,193 @@
+package git
+
+import (
+	"container/heap"
+	"sort"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// maxCandidates is the number of tags considered when describing a revision,
+// the default of git describe --candidates
+const maxCandidates = 10
+
+// candidate is a tagged commit found while describing a revision
+type candidate struct {
+	tag Tag
+	// depth is the number of commits walked that the tag doesn't reach
+	depth int
+	flag  uint
+}
+
+// Describe returns the nearest of the tags to a revision of a git repository
+// at a known location, along with the number of commits reachable from the
+// revision but not from the tag. Like git describe, the history is walked
+// once, newest commit first, looking up tagged commits as they're found.
+// Tags on the same commit, and tags as near as each other, are ranked by
+// prefer, which reports whether a tag is preferred over another. The tag is
+// empty when none of the tags are reachable, and the distance is then the
+// number of commits reachable from the revision.
+func Describe(path string, rev string, tags []Tag, prefer func(a, b Tag) bool) (Tag, int, error) {
+	r, err := open(path)
+	if err != nil {
+		return Tag{}, 0, err
+	}
+
+	h, err := resolve(r, rev)
+	if err != nil {
+		return Tag{}, 0, err
+	}
+
+	tagged := make(map[plumbing.Hash]Tag, len(tags))
+	for _, t := range tags {
+		if t.Commit == "" {
+			continue
+		}
+		c := plumbing.NewHash(t.Commit)
+		if prev, ok := tagged[c]; !ok || prefer(t, prev) {
+			tagged[c] = t
+		}
+	}
+
+	w := &walk{repo: r, flags: make(map[plumbing.Hash]uint), seen: make(map[plumbing.Hash]int)}
+	if err := w.push(h); err != nil {
+		return Tag{}, 0, err
+	}
+
+	walked := 0
+	cands := make([]*candidate, 0, maxCandidates)
+	var gaveUp *object.Commit
+	for w.Len() > 0 {
+		c := heap.Pop(w).(*object.Commit)
+		walked++
+
+		if t, ok := tagged[c.Hash]; ok {
+			if len(cands) == maxCandidates {
+				gaveUp = c
+				break
+			}
+			cand := &candidate{tag: t, depth: walked - 1, flag: 1 << uint(len(cands))}
+			cands = append(cands, cand)
+			w.flags[c.Hash] |= cand.flag
+		}
+
+		for _, cand := range cands {
+			if w.flags[c.Hash]&cand.flag == 0 {
+				cand.depth++
+			}
+		}
+
+		if err := w.parents(c); err != nil {
+			return Tag{}, 0, err
+		}
+	}
+
+	if len(cands) == 0 {
+		return Tag{}, walked, nil
+	}
+
+	sort.SliceStable(cands, func(i, j int) bool {
+		if cands[i].depth != cands[j].depth {
+			return cands[i].depth < cands[j].depth
+		}
+		return prefer(cands[i].tag, cands[j].tag)
+	})
+	best := cands[0]
+
+	if gaveUp != nil {
+		heap.Push(w, gaveUp)
+	}
+
+	// count the commits left that the best tag doesn't reach, until every
+	// commit left to walk is reached by it
+	for w.Len() > 0 {
+		c := heap.Pop(w).(*object.Commit)
+		if w.flags[c.Hash]&best.flag != 0 {
+			if w.within(best.flag) {
+				break
+			}
+		} else {
+			best.depth++
+		}
+
+		if err := w.parents(c); err != nil {
+			return Tag{}, 0, err
+		}
+	}
+	return best.tag, best.depth, nil
+}
+
+// walk is the queue of commits to walk, newest first, along with the flags of
+// the candidates reaching each commit
+type walk struct {
+	repo    *git.Repository
+	commits []*object.Commit
+	flags   map[plumbing.Hash]uint
+	// seen holds the order commits were queued in, which breaks ties
+	// between commits made at the same time
+	seen map[plumbing.Hash]int
+}
+
+func (w *walk) Len() int {
+	return len(w.commits)
+}
+
+func (w *walk) Less(i, j int) bool {
+	a, b := w.commits[i], w.commits[j]
+	if !a.Committer.When.Equal(b.Committer.When) {
+		return a.Committer.When.After(b.Committer.When)
+	}
+	return w.seen[a.Hash] < w.seen[b.Hash]
+}
+
+func (w *walk) Swap(i, j int) {
+	w.commits[i], w.commits[j] = w.commits[j], w.commits[i]
+}
+
+func (w *walk) Push(x interface{}) {
+	w.commits = append(w.commits, x.(*object.Commit))
+}
+
+func (w *walk) Pop() interface{} {
+	c := w.commits[len(w.commits)-1]
+	w.commits = w.commits[:len(w.commits)-1]
+	return c
+}
+
+// push queues a commit that wasn't seen yet
+func (w *walk) push(h plumbing.Hash) error {
+	if _, ok := w.seen[h]; ok {
+		return nil
+	}
+	w.seen[h] = len(w.seen)
+
+	c, err := w.repo.CommitObject(h)
+	if err != nil {
+		return err
+	}
+	heap.Push(w, c)
+	return nil
+}
+
+// parents queues the parents of a commit, passing on its flags
+func (w *walk) parents(c *object.Commit) error {
+	for _, p := range c.ParentHashes {
+		if err := w.push(p); err != nil {
+			return err
+		}
+		w.flags[p] |= w.flags[c.Hash]
+	}
+	return nil
+}
+
+// within reports whether every queued commit has a flag
+func (w *walk) within(flag uint) bool {
+	for _, c := range w.commits {
+		if w.flags[c.Hash]&flag == 0 {
+			return false
+		}
+	}
+	return true
+}
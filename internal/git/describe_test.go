@@ -0,0 +1,95 @@
+package git
+
+import (
+	"testing"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// preferName prefers the tag with the greater name
+func preferName(a, b Tag) bool {
+	return a.Name > b.Name
+}
+
+// TestDescribe verifies the nearest tag and the distance to it are found
+func TestDescribe(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.commit("first")
+	second := tr.commit("second")
+	third := tr.commit("third")
+	tr.commit("fourth")
+
+	tags := []Tag{
+		{Name: "v0.1.0", Commit: second.String()},
+		{Name: "v0.1.9", Commit: third.String()},
+		{Name: "v0.2.0", Commit: third.String()},
+	}
+
+	tests := []struct {
+		rev      string
+		tags     []Tag
+		expected string
+		distance int
+	}{
+		{"HEAD", tags, "v0.2.0", 1},
+		{"HEAD~1", tags, "v0.2.0", 0},
+		{"HEAD", tags[:1], "v0.1.0", 2},
+		{"HEAD~3", tags, "", 1},
+		{"HEAD", nil, "", 4},
+	}
+
+	for _, tc := range tests {
+		tag, distance, err := Describe(tr.dir, tc.rev, tc.tags, preferName)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if tag.Name != tc.expected || distance != tc.distance {
+			t.Fatalf("expected %s to be described by '%s' at %d, found '%s' at %d", tc.rev, tc.expected, tc.distance, tag.Name, distance)
+		}
+	}
+}
+
+// TestDescribeMerge verifies tags on merged branches are found and commits
+// reachable from the tag aren't counted
+func TestDescribeMerge(t *testing.T) {
+	tr := newTestRepo(t)
+	first := tr.commit("first")
+	tr.checkout("feature", first)
+	feature := tr.commit("feature")
+	tr.checkout("main", first)
+	main := tr.commit("main")
+
+	w, err := tr.repo.Worktree()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	tr.when = tr.when.Add(1)
+	_, err = w.Commit("merge", &git.CommitOptions{
+		Author:  &object.Signature{Name: "Test", Email: "test@example.com", When: tr.when},
+		Parents: []plumbing.Hash{main, feature},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tags := []Tag{
+		{Name: "v1.0.0", Commit: first.String()},
+		{Name: "v1.1.0", Commit: feature.String()},
+	}
+
+	tag, distance, err := Describe(tr.dir, "HEAD", tags, preferName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if tag.Name != "v1.1.0" || distance != 2 {
+		t.Fatalf("expected HEAD to be described by 'v1.1.0' at 2, found '%s' at %d", tag.Name, distance)
+	}
+
+	if _, _, err := Describe(tr.dir, "v9.9.9", tags, preferName); err == nil {
+		t.Fatal("expected error for unknown revision")
+	}
+}
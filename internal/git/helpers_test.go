@@ -0,0 +1,103 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// testRepo is a local git repository used by tests
+type testRepo struct {
+	t    *testing.T
+	dir  string
+	repo *git.Repository
+	when time.Time
+}
+
+// newTestRepo initializes a git repository in a temporary directory
+func newTestRepo(t *testing.T) *testRepo {
+	dir, err := ioutil.TempDir("", "go-semver-git")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	r, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	return &testRepo{
+		t:    t,
+		dir:  dir,
+		repo: r,
+		when: time.Date(2022, time.September, 19, 9, 18, 48, 0, time.UTC),
+	}
+}
+
+// commit writes a file and commits it with a message, returning the commit hash
+func (tr *testRepo) commit(msg string) plumbing.Hash {
+	tr.t.Helper()
+	w, err := tr.repo.Worktree()
+	if err != nil {
+		tr.t.Fatal(err.Error())
+	}
+
+	tr.when = tr.when.Add(time.Minute)
+	err = ioutil.WriteFile(filepath.Join(tr.dir, "file.txt"), []byte(msg), 0644)
+	if err != nil {
+		tr.t.Fatal(err.Error())
+	}
+
+	if _, err := w.Add("file.txt"); err != nil {
+		tr.t.Fatal(err.Error())
+	}
+
+	h, err := w.Commit(msg, &git.CommitOptions{
+		Author: &object.Signature{Name: "Test", Email: "test@example.com", When: tr.when},
+	})
+	if err != nil {
+		tr.t.Fatal(err.Error())
+	}
+	return h
+}
+
+// tag creates a lightweight tag, or an annotated tag if a message is given
+func (tr *testRepo) tag(name string, h plumbing.Hash, msg string) {
+	tr.t.Helper()
+	var opts *git.CreateTagOptions
+	if msg != "" {
+		opts = &git.CreateTagOptions{
+			Tagger:  &object.Signature{Name: "Test", Email: "test@example.com", When: tr.when},
+			Message: msg,
+		}
+	}
+
+	if _, err := tr.repo.CreateTag(name, h, opts); err != nil {
+		tr.t.Fatal(err.Error())
+	}
+}
+
+// checkout creates a branch at a commit and checks it out
+func (tr *testRepo) checkout(name string, h plumbing.Hash) {
+	tr.t.Helper()
+	w, err := tr.repo.Worktree()
+	if err != nil {
+		tr.t.Fatal(err.Error())
+	}
+
+	err = w.Checkout(&git.CheckoutOptions{
+		Hash:   h,
+		Branch: plumbing.NewBranchReferenceName(name),
+		Create: true,
+	})
+	if err != nil {
+		tr.t.Fatal(err.Error())
+	}
+}
@@ -0,0 +1,139 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+)
+
+var (
+	// ErrTagExists is returned when a tag being created or pushed already exists
+	ErrTagExists = git.ErrTagExists
+	// ErrMissingTagger is returned when an annotated tag has no tagger and none is configured in the repository
+	ErrMissingTagger = errors.New("tagger name and email are required for annotated tags")
+)
+
+// TagOptions describes how a tag is created
+type TagOptions struct {
+	// Ref is the revision to tag. HEAD is used when empty
+	Ref string
+	// Message is the tag annotation. A lightweight tag is created when empty
+	Message string
+	// TaggerName is the name of the tag creator. The user.name of the
+	// repository config is used when empty
+	TaggerName string
+	// TaggerEmail is the email of the tag creator. The user.email of the
+	// repository config is used when empty
+	TaggerEmail string
+}
+
+// CreateTag creates a tag in a git repository at a known location
+func CreateTag(path string, name string, opts *TagOptions) error {
+	if opts == nil {
+		opts = &TagOptions{}
+	}
+
+	r, err := open(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.Tag(name); err == nil {
+		return ErrTagExists
+	} else if err != git.ErrTagNotFound {
+		return err
+	}
+
+	ref := opts.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	h, err := r.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return fmt.Errorf("unable to resolve %s: %w", ref, err)
+	}
+
+	var copts *git.CreateTagOptions
+	if opts.Message != "" {
+		tagger, err := signature(r, opts.TaggerName, opts.TaggerEmail)
+		if err != nil {
+			return err
+		}
+
+		copts = &git.CreateTagOptions{
+			Tagger:  tagger,
+			Message: opts.Message,
+		}
+	}
+
+	_, err = r.CreateTag(name, *h, copts)
+	return err
+}
+
+// PushTag pushes a tag from a git repository at a known location to a named
+// remote. The push is refused if the remote already has the tag.
+func PushTag(path string, remote string, name string) error {
+	r, err := open(path)
+	if err != nil {
+		return err
+	}
+
+	rem, err := r.Remote(remote)
+	if err != nil {
+		return err
+	}
+
+	rname := plumbing.NewTagReferenceName(name)
+	refs, err := rem.List(&git.ListOptions{})
+	if err != nil && err != transport.ErrEmptyRemoteRepository {
+		return err
+	}
+
+	for _, ref := range refs {
+		if ref.Name() == rname {
+			return ErrTagExists
+		}
+	}
+
+	spec := config.RefSpec(fmt.Sprintf("%s:%s", rname, rname))
+	return rem.Push(&git.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []config.RefSpec{spec},
+	})
+}
+
+// signature returns the tagger signature, falling back to the user
+// configured in the repository
+func signature(r *git.Repository, name, email string) (*object.Signature, error) {
+	if name == "" || email == "" {
+		cfg, err := r.Config()
+		if err != nil {
+			return nil, err
+		}
+
+		user := cfg.Raw.Section("user")
+		if name == "" {
+			name = user.Option("name")
+		}
+		if email == "" {
+			email = user.Option("email")
+		}
+	}
+
+	if name == "" || email == "" {
+		return nil, ErrMissingTagger
+	}
+
+	return &object.Signature{
+		Name:  name,
+		Email: email,
+		When:  time.Now(),
+	}, nil
+}
@@ -0,0 +1,95 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+)
+
+// TestCreateTag verifies lightweight and annotated tags are created on the requested revision
+func TestCreateTag(t *testing.T) {
+	tr := newTestRepo(t)
+	first := tr.commit("first")
+	head := tr.commit("second")
+
+	if err := CreateTag(tr.dir, "v0.1.0", &TagOptions{Ref: first.String()}); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	opts := &TagOptions{Message: "Release v0.2.0", TaggerName: "Tagger", TaggerEmail: "tagger@example.com"}
+	if err := CreateTag(tr.dir, "v0.2.0", opts); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	ref, err := tr.repo.Tag("v0.1.0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if ref.Hash() != first {
+		t.Fatalf("expected lightweight tag on %s, found %s", first, ref.Hash())
+	}
+
+	ref, err = tr.repo.Tag("v0.2.0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	obj, err := tr.repo.TagObject(ref.Hash())
+	if err != nil {
+		t.Fatalf("expected annotated tag: %s", err)
+	}
+	if obj.Target != head {
+		t.Fatalf("expected annotated tag on %s, found %s", head, obj.Target)
+	}
+	if obj.Tagger.Email != "tagger@example.com" {
+		t.Fatalf("expected tagger email 'tagger@example.com', found '%s'", obj.Tagger.Email)
+	}
+
+	if err := CreateTag(tr.dir, "v0.2.0", nil); err != ErrTagExists {
+		t.Fatalf("expected error '%v', found '%v'", ErrTagExists, err)
+	}
+
+	if err := CreateTag(tr.dir, "v0.3.0", &TagOptions{Message: "Release v0.3.0"}); err != ErrMissingTagger {
+		t.Fatalf("expected error '%v', found '%v'", ErrMissingTagger, err)
+	}
+}
+
+// TestPushTag verifies a tag is pushed to a bare remote and not pushed twice
+func TestPushTag(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.commit("first")
+
+	dir, err := ioutil.TempDir("", "go-semver-git-remote")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	bare, err := git.PlainInit(dir, true)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	_, err = tr.repo.CreateRemote(&config.RemoteConfig{Name: "upstream", URLs: []string{dir}})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if err := CreateTag(tr.dir, "v1.0.0", nil); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if err := PushTag(tr.dir, "upstream", "v1.0.0"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if _, err := bare.Tag("v1.0.0"); err != nil {
+		t.Fatalf("expected tag on remote: %s", err)
+	}
+
+	if err := PushTag(tr.dir, "upstream", "v1.0.0"); err != ErrTagExists {
+		t.Fatalf("expected error '%v', found '%v'", ErrTagExists, err)
+	}
+}
@@ -2,13 +2,25 @@ package git
 
 import (
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
 
 	"gopkg.in/src-d/go-git.v4"
 )
 
+var (
+	// ErrRepositoryNotExists is returned when a location isn't inside a git repository
+	ErrRepositoryNotExists = git.ErrRepositoryNotExists
+	// ErrIsBareRepository is returned when a bare git repository has no working tree
+	ErrIsBareRepository = git.ErrIsBareRepository
+)
+
 // rootPath isn't really getting the root path. But it does try to make sure that the path specified is a valid directory
 func rootPath(path string) (string, error) {
 	if path == "" {
@@ -36,32 +48,225 @@ func rootPath(path string) (string, error) {
 	return path, nil
 }
 
+// open opens the git repository containing a known location
+func open(path string) (*git.Repository, error) {
+	apath, err := rootPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &git.PlainOpenOptions{DetectDotGit: true}
+	return git.PlainOpenWithOptions(apath, options)
+}
+
+// Root returns the root directory of the working tree of the git repository
+// containing a known location
+func Root(path string) (string, error) {
+	r, err := open(path)
+	if err != nil {
+		return "", err
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return "", err
+	}
+	return w.Filesystem.Root(), nil
+}
+
+// ListOptions restricts the tags that are listed
+type ListOptions struct {
+	// ReachableFrom only lists tags whose target commit is an ancestor of,
+	// or the same as, this revision. All tags are listed when empty
+	ReachableFrom string
+	// Prefix only lists tags starting with this prefix and strips it from
+	// the listed values, e.g. "services/api/" lists "services/api/v1.4.0"
+	// as "v1.4.0"
+	Prefix string
+	// Pattern only lists tags whose full name matches this glob, e.g.
+	// "services/*/v*", or this regular expression when wrapped in slashes,
+	// e.g. "/^release-[0-9]+/"
+	Pattern string
+}
+
+// TagName returns the full tag name of a version by re-applying the prefix.
+// The version is used as is, so it should keep any "v" of the tag it was
+// listed from
+func (o *ListOptions) TagName(version string) string {
+	if o == nil {
+		return version
+	}
+	return o.Prefix + version
+}
+
+// matcher returns a function reporting whether a tag name matches the pattern
+func (o *ListOptions) matcher() (func(string) bool, error) {
+	p := o.Pattern
+	if p == "" {
+		return func(string) bool { return true }, nil
+	}
+
+	if len(p) > 1 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/") {
+		re, err := regexp.Compile(p[1 : len(p)-1])
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+
+	if _, err := path.Match(p, ""); err != nil {
+		return nil, err
+	}
+	return func(name string) bool {
+		ok, _ := path.Match(p, name)
+		return ok
+	}, nil
+}
+
+// Filter returns the names that match the pattern and start with the prefix
+// of the list options, with the prefix stripped, restricting tag names from
+// other sources the way tags of a git repository are restricted
+func (o *ListOptions) Filter(names []string) ([]string, error) {
+	if o == nil {
+		o = &ListOptions{}
+	}
+
+	match, err := o.matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]string, 0, len(names))
+	for _, name := range names {
+		if match(name) && strings.HasPrefix(name, o.Prefix) {
+			r = append(r, strings.TrimPrefix(name, o.Prefix))
+		}
+	}
+	return r, nil
+}
+
 // Tags returns a list of tag values from a git repository at a known location
 func Tags(path string) ([]string, error) {
+	return TagsWithOptions(path, nil)
+}
+
+// Tag is a tag in a git repository
+type Tag struct {
+	// Name is the tag name, with the prefix of the list options stripped
+	Name string
+	// Commit is the hash of the commit the tag points to. It is empty for
+	// tags of anything other than a commit
+	Commit string
+	// Date is when the tag was made, i.e. the tagger date of an annotated
+	// tag or the author time of the commit of a lightweight tag
+	Date time.Time
+}
+
+// TagsWithOptions returns a list of tag values from a git repository at a
+// known location, restricted by the list options
+func TagsWithOptions(path string, opts *ListOptions) ([]string, error) {
 	var stags []string
-	apath, err := rootPath(path)
+	tags, err := ListTags(path, opts)
 	if err != nil {
 		return stags, err
 	}
 
-	options := &git.PlainOpenOptions{DetectDotGit: true}
-	r, err := git.PlainOpenWithOptions(apath, options)
+	stags = make([]string, len(tags))
+	for i, t := range tags {
+		stags[i] = t.Name
+	}
+	return stags, nil
+}
+
+// ListTags returns the tags, along with the commits they point to, from a
+// git repository at a known location, restricted by the list options
+func ListTags(path string, opts *ListOptions) ([]Tag, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+
+	match, err := opts.matcher()
 	if err != nil {
-		return stags, err
+		return nil, err
+	}
+
+	r, err := open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var reachable map[plumbing.Hash]bool
+	if opts.ReachableFrom != "" {
+		h, err := resolve(r, opts.ReachableFrom)
+		if err != nil {
+			return nil, err
+		}
+
+		reachable, err = ancestors(r, h)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// all tag references, both lightweight tags and annotated tags
-	tags, err := r.Tags()
+	refs, err := r.Tags()
 	if err != nil {
-		return stags, err
+		return nil, err
 	}
 
-	stags = make([]string, 0)
+	tags := make([]Tag, 0)
+
+	err = refs.ForEach(func(t *plumbing.Reference) error {
+		name := t.Name().Short()
+		if !match(name) || !strings.HasPrefix(name, opts.Prefix) {
+			return nil
+		}
+
+		h, date, err := target(r, t)
+		if err != nil {
+			return err
+		}
 
-	err = tags.ForEach(func(t *plumbing.Reference) error {
-		stags = append(stags, t.Name().Short())
+		if reachable != nil && !reachable[h] {
+			return nil
+		}
+
+		tag := Tag{Name: strings.TrimPrefix(name, opts.Prefix), Date: date}
+		if !h.IsZero() {
+			tag.Commit = h.String()
+		}
+		tags = append(tags, tag)
 		return nil
 	})
 
-	return stags, err
+	return tags, err
+}
+
+// target returns the hash of the commit a tag points to, along with the date
+// of the tag. Annotated tags are peeled, and tags of anything other than a
+// commit return a zero hash
+func target(r *git.Repository, t *plumbing.Reference) (plumbing.Hash, time.Time, error) {
+	obj, err := r.TagObject(t.Hash())
+	switch err {
+	case nil:
+		c, err := obj.Commit()
+		if err == object.ErrUnsupportedObject {
+			return plumbing.ZeroHash, obj.Tagger.When, nil
+		}
+		if err != nil {
+			return plumbing.ZeroHash, time.Time{}, err
+		}
+		return c.Hash, obj.Tagger.When, nil
+
+	case plumbing.ErrObjectNotFound:
+		// lightweight tag, which has no date of its own
+		var date time.Time
+		if c, err := r.CommitObject(t.Hash()); err == nil {
+			date = c.Author.When
+		}
+		return t.Hash(), date, nil
+
+	default:
+		return plumbing.ZeroHash, time.Time{}, err
+	}
 }
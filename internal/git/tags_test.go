@@ -3,7 +3,9 @@ package git
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"gopkg.in/src-d/go-git.v4"
 )
@@ -75,3 +77,195 @@ func TestTags(t *testing.T) {
 		}
 	}
 }
+
+// TestTagsReachableFrom verifies only the tags reachable from a revision are returned
+func TestTagsReachableFrom(t *testing.T) {
+	tr := newTestRepo(t)
+	first := tr.commit("first")
+	tr.tag("v1.0.0", first, "")
+	second := tr.commit("second")
+	tr.tag("v2.0.0", second, "Release v2.0.0")
+
+	tr.checkout("release-1.x", first)
+	fix := tr.commit("fix")
+	tr.tag("v1.0.1", fix, "Release v1.0.1")
+
+	tests := []struct {
+		ref      string
+		expected []string
+	}{
+		{"", []string{"v1.0.0", "v1.0.1", "v2.0.0"}},
+		{"HEAD", []string{"v1.0.0", "v1.0.1"}},
+		{"release-1.x", []string{"v1.0.0", "v1.0.1"}},
+		{"master", []string{"v1.0.0", "v2.0.0"}},
+		{"v1.0.0", []string{"v1.0.0"}},
+	}
+
+	for _, tc := range tests {
+		tags, err := TagsWithOptions(tr.dir, &ListOptions{ReachableFrom: tc.ref})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if len(tags) != len(tc.expected) {
+			t.Fatalf("expected %d tags reachable from '%s', found %d tags", len(tc.expected), tc.ref, len(tags))
+		}
+
+		for i, a := range tags {
+			if tc.expected[i] != a {
+				t.Fatalf("expected tag value '%s', found tag value '%s'", tc.expected[i], a)
+			}
+		}
+	}
+}
+
+// TestTagsPrefix verifies tags are filtered by prefix and pattern
+func TestTagsPrefix(t *testing.T) {
+	tr := newTestRepo(t)
+	h := tr.commit("first")
+	for _, name := range []string{"v0.9.0", "services/api/v1.4.0", "services/api/v1.3.2", "services/web/v2.0.0", "libs/auth/v0.3.1", "release-7"} {
+		tr.tag(name, h, "")
+	}
+
+	tests := []struct {
+		opts     ListOptions
+		expected []string
+		err      bool
+	}{
+		{ListOptions{Prefix: "services/api/"}, []string{"v1.3.2", "v1.4.0"}, false},
+		{ListOptions{Prefix: "libs/auth/v"}, []string{"0.3.1"}, false},
+		{ListOptions{Pattern: "services/*/v*"}, []string{"services/api/v1.3.2", "services/api/v1.4.0", "services/web/v2.0.0"}, false},
+		{ListOptions{Pattern: "/^v[0-9]/"}, []string{"v0.9.0"}, false},
+		{ListOptions{Prefix: "services/", Pattern: "*/web/*"}, []string{"web/v2.0.0"}, false},
+		{ListOptions{Pattern: "/^v[0-9/"}, nil, true},
+		{ListOptions{Pattern: "[v"}, nil, true},
+	}
+
+	for _, tc := range tests {
+		tags, err := TagsWithOptions(tr.dir, &tc.opts)
+		if tc.err {
+			if err == nil {
+				t.Fatalf("expected error for pattern '%s'", tc.opts.Pattern)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if len(tags) != len(tc.expected) {
+			t.Fatalf("expected %d tags for %+v, found %d tags", len(tc.expected), tc.opts, len(tags))
+		}
+
+		for i, a := range tags {
+			if tc.expected[i] != a {
+				t.Fatalf("expected tag value '%s', found tag value '%s'", tc.expected[i], a)
+			}
+		}
+	}
+}
+
+// TestTagName verifies the prefix is re-applied to versions
+func TestTagName(t *testing.T) {
+	tests := []struct {
+		opts     *ListOptions
+		version  string
+		expected string
+	}{
+		{nil, "1.2.3", "1.2.3"},
+		{&ListOptions{}, "v1.2.3", "v1.2.3"},
+		{&ListOptions{Prefix: "services/api/"}, "1.4.1", "services/api/1.4.1"},
+		{&ListOptions{Prefix: "services/api/"}, "v1.4.1", "services/api/v1.4.1"},
+		{&ListOptions{Prefix: "api-"}, "1.4.1", "api-1.4.1"},
+	}
+
+	for _, tc := range tests {
+		if n := tc.opts.TagName(tc.version); n != tc.expected {
+			t.Fatalf("expected tag name '%s', found '%s'", tc.expected, n)
+		}
+	}
+}
+
+// TestFilter verifies tag names from other sources are restricted like tags
+func TestFilter(t *testing.T) {
+	names := []string{"1.0.0", "api-1.1.0", "api-1.2.0-rc.1", "web-2.0.0", "latest"}
+
+	tests := []struct {
+		opts     *ListOptions
+		expected []string
+	}{
+		{nil, names},
+		{&ListOptions{Prefix: "api-"}, []string{"1.1.0", "1.2.0-rc.1"}},
+		{&ListOptions{Pattern: "*-*.0"}, []string{"api-1.1.0", "web-2.0.0"}},
+		{&ListOptions{Prefix: "api-", Pattern: "/^[a-z]+-[0-9.]+$/"}, []string{"1.1.0"}},
+	}
+
+	for _, tc := range tests {
+		filtered, err := tc.opts.Filter(names)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if len(filtered) != len(tc.expected) {
+			t.Fatalf("expected %d tags, found %d tags", len(tc.expected), len(filtered))
+		}
+
+		for i, a := range filtered {
+			if tc.expected[i] != a {
+				t.Fatalf("expected tag value '%s', found tag value '%s'", tc.expected[i], a)
+			}
+		}
+	}
+}
+
+// TestListTags verifies tags are listed with the commits they point to
+func TestListTags(t *testing.T) {
+	tr := newTestRepo(t)
+	first := tr.commit("first")
+	tr.tag("v1.0.0", first, "")
+	second := tr.commit("second")
+	committed := tr.when
+	tr.when = tr.when.Add(24 * time.Hour)
+	tr.tag("v1.1.0", second, "Release v1.1.0")
+
+	tags, err := ListTags(tr.dir, nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := []Tag{
+		{Name: "v1.0.0", Commit: first.String(), Date: committed.Add(-time.Minute)},
+		{Name: "v1.1.0", Commit: second.String(), Date: tr.when},
+	}
+
+	if len(tags) != len(expected) {
+		t.Fatalf("expected %d tags, found %d tags", len(expected), len(tags))
+	}
+
+	for i, a := range tags {
+		if expected[i].Name != a.Name || expected[i].Commit != a.Commit || !expected[i].Date.Equal(a.Date) {
+			t.Fatalf("expected tag %+v, found tag %+v", expected[i], a)
+		}
+	}
+}
+
+func TestRoot(t *testing.T) {
+	tr := newTestRepo(t)
+	sub := filepath.Join(tr.dir, "services", "api")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	root, err := Root(sub)
+	if err != nil {
+		t.Fatalf("error finding root of %s: %s", sub, err)
+	}
+
+	if root != tr.dir {
+		t.Fatalf("expected root %s, but got %s", tr.dir, root)
+	}
+
+	if _, err := Root(os.TempDir()); err != git.ErrRepositoryNotExists {
+		t.Fatalf("expected error %q outside of a repository, but got: %v", git.ErrRepositoryNotExists, err)
+	}
+}
@@ -0,0 +1,140 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// defaultTimeout is the time reading an index from a URL may take when no
+// HTTP client is given
+const defaultTimeout = 30 * time.Second
+
+var (
+	// ErrChartNotFound is returned when the index has no entries for a chart
+	ErrChartNotFound = errors.New("chart not found")
+	// ErrChartRequired is returned when the chart isn't named and the index
+	// holds more than one chart
+	ErrChartRequired = errors.New("the index holds several charts, a chart name is required")
+)
+
+// Entry is a version of a chart listed in a chart repository index
+type Entry struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	AppVersion string `yaml:"appVersion"`
+}
+
+// Index is the index.yaml of a chart repository
+type Index struct {
+	APIVersion string             `yaml:"apiVersion"`
+	Entries    map[string][]Entry `yaml:"entries"`
+}
+
+// ReadIndex reads the index of a chart repository from a local file or from
+// an http(s) URL. A URL that doesn't name a yaml file is the URL of the chart
+// repository, and its index.yaml is read. The index is read with a client
+// timing out after 30 seconds when no client is given.
+func ReadIndex(location string, client *http.Client) (*Index, error) {
+	var b []byte
+	var err error
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		b, err = fetch(location, client)
+	} else {
+		b, err = ioutil.ReadFile(location)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var idx Index
+	if err := yaml.Unmarshal(b, &idx); err != nil {
+		return nil, fmt.Errorf("%s: invalid index: %w", location, err)
+	}
+	if idx.Entries == nil {
+		return nil, fmt.Errorf("%s: invalid index: no entries", location)
+	}
+	return &idx, nil
+}
+
+// fetch returns the body of the index at a URL
+func fetch(location string, client *http.Client) ([]byte, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(u.Path, ".yaml") && !strings.HasSuffix(u.Path, ".yml") {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/index.yaml"
+	}
+
+	resp, err := httpClient(client).Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: unexpected response %s", u, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// httpClient returns the client to read an index with, a client with the
+// default timeout when none is given
+func httpClient(c *http.Client) *http.Client {
+	if c == nil {
+		return &http.Client{Timeout: defaultTimeout}
+	}
+	return c
+}
+
+// Charts returns the names of the charts in the index, sorted
+func (idx *Index) Charts() []string {
+	names := make([]string, 0, len(idx.Entries))
+	for n := range idx.Entries {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Versions returns the versions of a chart listed in the index, or the app
+// versions when appVersion is set. The chart may be omitted when the index
+// holds a single chart. App versions shared by several chart versions are
+// only returned once, and entries without an app version are skipped.
+func (idx *Index) Versions(chart string, appVersion bool) ([]string, error) {
+	if chart == "" {
+		charts := idx.Charts()
+		if len(charts) != 1 {
+			return nil, fmt.Errorf("%w: %s", ErrChartRequired, strings.Join(charts, ", "))
+		}
+		chart = charts[0]
+	}
+
+	entries, ok := idx.Entries[chart]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", chart, ErrChartNotFound)
+	}
+
+	versions := make([]string, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		v := e.Version
+		if appVersion {
+			v = e.AppVersion
+		}
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
@@ -0,0 +1,134 @@
+package helm
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const indexYAML = `apiVersion: v1
+entries:
+  api:
+  - name: api
+    version: 0.3.0
+    appVersion: 1.2.0
+  - name: api
+    version: 0.2.1
+    appVersion: 1.2.0
+  - name: api
+    version: 0.2.0
+  web:
+  - name: web
+    version: 2.0.0-rc.1
+    appVersion: v3.1.0
+generated: "2022-09-19T10:00:00Z"
+`
+
+func writeIndex(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "index.yaml")
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("unable to write index: %s", err)
+	}
+	return path
+}
+
+func TestVersions(t *testing.T) {
+	idx, err := ReadIndex(writeIndex(t, indexYAML), nil)
+	if err != nil {
+		t.Fatalf("unable to read index: %s", err)
+	}
+
+	tests := []struct {
+		chart      string
+		appVersion bool
+		expected   []string
+		err        error
+	}{
+		{"api", false, []string{"0.3.0", "0.2.1", "0.2.0"}, nil},
+		{"api", true, []string{"1.2.0"}, nil},
+		{"web", false, []string{"2.0.0-rc.1"}, nil},
+		{"web", true, []string{"v3.1.0"}, nil},
+		{"db", false, nil, ErrChartNotFound},
+		{"", false, nil, ErrChartRequired},
+	}
+
+	for _, tc := range tests {
+		versions, err := idx.Versions(tc.chart, tc.appVersion)
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %q for %s, but got %v", tc.err, tc.chart, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %s", tc.chart, err)
+		}
+		if !reflect.DeepEqual(versions, tc.expected) {
+			t.Fatalf("expected %v for %s, but got %v", tc.expected, tc.chart, versions)
+		}
+	}
+}
+
+func TestVersionsSingleChart(t *testing.T) {
+	idx, err := ReadIndex(writeIndex(t, "apiVersion: v1\nentries:\n  app:\n  - version: 1.0.0\n"), nil)
+	if err != nil {
+		t.Fatalf("unable to read index: %s", err)
+	}
+
+	versions, err := idx.Versions("", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(versions, []string{"1.0.0"}) {
+		t.Fatalf("expected [1.0.0], but got %v", versions)
+	}
+}
+
+func TestReadIndexURL(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/charts/index.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(indexYAML))
+	}))
+	defer s.Close()
+
+	for _, u := range []string{s.URL + "/charts", s.URL + "/charts/", s.URL + "/charts/index.yaml"} {
+		idx, err := ReadIndex(u, s.Client())
+		if err != nil {
+			t.Fatalf("unable to read index from %s: %s", u, err)
+		}
+		if !reflect.DeepEqual(idx.Charts(), []string{"api", "web"}) {
+			t.Fatalf("expected charts [api web] from %s, but got %v", u, idx.Charts())
+		}
+	}
+
+	if _, err := ReadIndex(s.URL+"/missing", s.Client()); err == nil {
+		t.Fatalf("expected an error for a missing index, but got none")
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	if c := httpClient(nil); c.Timeout != defaultTimeout {
+		t.Fatalf("expected a timeout of %s, but got %s", defaultTimeout, c.Timeout)
+	}
+}
+
+func TestReadIndexInvalid(t *testing.T) {
+	tests := []string{
+		"entries: [",
+		"apiVersion: v1\n",
+	}
+
+	for _, content := range tests {
+		if _, err := ReadIndex(writeIndex(t, content), nil); err == nil {
+			t.Fatalf("expected an error for %q, but got none", content)
+		}
+	}
+}
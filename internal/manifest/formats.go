@@ -0,0 +1,163 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// format locates the byte range of the value of a field in a manifest
+type format func(b []byte, field string) (int, int, bool)
+
+// formatOf returns the format of a manifest file along with the fields
+// tried, in order, when a spec has no field
+func formatOf(path string) (format, []string, error) {
+	base := filepath.Base(path)
+	switch ext := strings.ToLower(filepath.Ext(base)); {
+	case ext == ".json":
+		return locateJSON, []string{"version"}, nil
+	case ext == ".yaml" || ext == ".yml":
+		return locateYAML, []string{"version"}, nil
+	case base == "Cargo.toml":
+		return locateTOML, []string{"package.version", "workspace.package.version"}, nil
+	case base == "pyproject.toml":
+		return locateTOML, []string{"project.version", "tool.poetry.version"}, nil
+	case ext == ".toml":
+		return locateTOML, []string{"version"}, nil
+	case ext == ".go":
+		return locateGo, []string{"Version"}, nil
+	case ext == "" || ext == ".txt":
+		return locatePlain, []string{""}, nil
+	}
+	return nil, nil, fmt.Errorf("%s: %w", path, ErrUnknownFormat)
+}
+
+// locate returns the byte range of the version held in the content of a
+// manifest file
+func locate(spec Spec, b []byte) (int, int, error) {
+	f, fields, err := formatOf(spec.Path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if spec.Field != "" {
+		fields = []string{spec.Field}
+	}
+	for _, field := range fields {
+		if start, end, ok := f(b, field); ok {
+			return start, end, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("%s: %w", spec, ErrFieldNotFound)
+}
+
+// locateJSON locates the string value of a top level key of a JSON object
+func locateJSON(b []byte, field string) (int, int, bool) {
+	depth := 0
+	key, value := "", false
+	for i := 0; i < len(b); i++ {
+		switch b[i] {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case ':':
+			value = depth == 1
+		case ',':
+			value = false
+		case '"':
+			end := i + 1
+			for ; end < len(b) && b[end] != '"'; end++ {
+				if b[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(b) {
+				return 0, 0, false
+			}
+
+			if depth == 1 {
+				if value && key == field {
+					return i + 1, end, true
+				}
+				if !value {
+					key = string(b[i+1 : end])
+				}
+			}
+			i = end
+		}
+	}
+	return 0, 0, false
+}
+
+// locateYAML locates the scalar value of a top level key of a YAML document
+func locateYAML(b []byte, field string) (int, int, bool) {
+	re := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(field) + `:[ \t]*(?:"([^"\n]*)"|'([^'\n]*)'|([^\s#"'][^\s#]*))`)
+	return submatch(re.FindSubmatchIndex(b))
+}
+
+// tomlTable matches a TOML table header, or an array of tables header such
+// as [[bin]]
+var tomlTable = regexp.MustCompile(`^\s*(\[\[?)\s*([^\[\]]+?)\s*\]`)
+
+// locateTOML locates the string value of a dotted key, the last part of
+// which is the key in the table named by the rest, e.g. package.version
+func locateTOML(b []byte, field string) (int, int, bool) {
+	table, key := "", field
+	if i := strings.LastIndex(field, "."); i >= 0 {
+		table, key = field[:i], field[i+1:]
+	}
+	re := regexp.MustCompile(`^\s*` + regexp.QuoteMeta(key) + `\s*=\s*(?:"([^"\n]*)"|'([^'\n]*)')`)
+
+	current, offset := "", 0
+	for _, line := range bytes.SplitAfter(b, []byte("\n")) {
+		if m := tomlTable.FindSubmatch(line); m != nil {
+			// the keys of an array of tables are never those of a table
+			current = string(m[2])
+			if len(m[1]) == 2 {
+				current = "[[" + current + "]]"
+			}
+		} else if current == table {
+			if start, end, ok := submatch(re.FindSubmatchIndex(line)); ok {
+				return offset + start, offset + end, true
+			}
+		}
+		offset += len(line)
+	}
+	return 0, 0, false
+}
+
+// locateGo locates the string value of a constant or variable in Go source
+func locateGo(b []byte, field string) (int, int, bool) {
+	re := regexp.MustCompile(`(?m)^\s*(?:(?:const|var)\s+)?` + regexp.QuoteMeta(field) + `(?:\s+string)?\s*=\s*(?:"([^"\n]*)"|` + "`([^`\n]*)`)")
+	return submatch(re.FindSubmatchIndex(b))
+}
+
+// locatePlain locates the first non-blank line of a plain text file
+func locatePlain(b []byte, field string) (int, int, bool) {
+	if field != "" {
+		return 0, 0, false
+	}
+
+	offset := 0
+	for _, line := range bytes.SplitAfter(b, []byte("\n")) {
+		if t := bytes.TrimSpace(line); len(t) > 0 {
+			start := offset + bytes.Index(line, t)
+			return start, start + len(t), true
+		}
+		offset += len(line)
+	}
+	return 0, 0, false
+}
+
+// submatch returns the range of the first alternative group that matched
+func submatch(loc []int) (int, int, bool) {
+	for i := 2; i+1 < len(loc); i += 2 {
+		if loc[i] >= 0 {
+			return loc[i], loc[i+1], true
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,166 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrUnknownFormat is returned for a file whose format can't be told from its name
+	ErrUnknownFormat = errors.New("unknown manifest format")
+	// ErrFieldNotFound is returned when a file doesn't hold the version field
+	ErrFieldNotFound = errors.New("version field not found")
+)
+
+// Spec identifies a version held in a manifest file
+type Spec struct {
+	// Path is the path of the manifest file
+	Path string
+	// Field is the field holding the version, e.g. appVersion in a Chart.yaml
+	// or package.version in a Cargo.toml. The default field of the format is
+	// used when empty.
+	Field string
+}
+
+// ParseSpec parses a spec in the form path[:field]
+func ParseSpec(s string) Spec {
+	if i := strings.LastIndex(s, ":"); i > 0 && !strings.ContainsAny(s[i+1:], `/\`) {
+		return Spec{Path: s[:i], Field: s[i+1:]}
+	}
+	return Spec{Path: s}
+}
+
+// String returns the spec in the form path[:field]
+func (s Spec) String() string {
+	if s.Field == "" {
+		return s.Path
+	}
+	return s.Path + ":" + s.Field
+}
+
+// Update is a new version for the version held in a manifest file
+type Update struct {
+	Spec    Spec
+	Version string
+}
+
+// Read returns the version held in a manifest file
+func Read(spec Spec) (string, error) {
+	b, err := ioutil.ReadFile(spec.Path)
+	if err != nil {
+		return "", err
+	}
+
+	start, end, err := locate(spec, b)
+	if err != nil {
+		return "", err
+	}
+	return string(b[start:end]), nil
+}
+
+// rename renames a file, replaced in tests to make renaming fail
+var rename = os.Rename
+
+// Write replaces the versions held in manifest files, leaving the rest of
+// each file untouched. Nothing is written unless every update can be
+// applied. Every file is staged next to the original, along with a backup of
+// the original, before any of them is renamed into place, and the originals
+// are restored when renaming fails.
+func Write(updates []Update) error {
+	order := make([]string, 0, len(updates))
+	originals := make(map[string][]byte, len(updates))
+	contents := make(map[string][]byte, len(updates))
+	for _, u := range updates {
+		b, ok := contents[u.Spec.Path]
+		if !ok {
+			var err error
+			b, err = ioutil.ReadFile(u.Spec.Path)
+			if err != nil {
+				return err
+			}
+			order = append(order, u.Spec.Path)
+			originals[u.Spec.Path] = b
+		}
+
+		start, end, err := locate(u.Spec, b)
+		if err != nil {
+			return err
+		}
+
+		nb := make([]byte, 0, len(b)-(end-start)+len(u.Version))
+		nb = append(nb, b[:start]...)
+		nb = append(nb, u.Version...)
+		nb = append(nb, b[end:]...)
+		contents[u.Spec.Path] = nb
+	}
+
+	staged := make([]string, 0, len(order))
+	backups := make([]string, 0, len(order))
+	cleanup := func() {
+		for _, tmp := range append(staged, backups...) {
+			os.Remove(tmp)
+		}
+	}
+
+	for _, path := range order {
+		tmp, err := stage(path, contents[path])
+		if err != nil {
+			cleanup()
+			return err
+		}
+		staged = append(staged, tmp)
+
+		bak, err := stage(path, originals[path])
+		if err != nil {
+			cleanup()
+			return err
+		}
+		backups = append(backups, bak)
+	}
+
+	for i, path := range order {
+		if err := rename(staged[i], path); err != nil {
+			// put back the files already replaced
+			for j := i - 1; j >= 0; j-- {
+				if rerr := rename(backups[j], order[j]); rerr != nil {
+					err = fmt.Errorf("%w, and restoring %s failed: %s", err, order[j], rerr)
+				}
+			}
+			cleanup()
+			return err
+		}
+	}
+	cleanup()
+	return nil
+}
+
+// stage writes the new content of a file to a temporary file in the same
+// directory, with the same permissions, and returns its path
+func stage(path string, b []byte) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), fi.Mode().Perm())
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("unable to stage %s: %w", path, err)
+	}
+	return f.Name(), nil
+}
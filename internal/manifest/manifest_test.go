@@ -0,0 +1,268 @@
+package manifest
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	packageJSON = `{
+  "name": "app",
+  "config": {"version": "9.9.9"},
+  "version": "1.2.3",
+  "scripts": {"test": "jest"}
+}
+`
+	chartYAML = `apiVersion: v2
+name: app
+# the chart version
+version: 0.4.1
+appVersion: "1.2.3" # the app version
+`
+	cargoTOML = `[package]
+name = "app"
+version = "0.3.0" # bumped by release
+
+[dependencies]
+serde = { version = "1.0" }
+`
+	binTOML = `[package]
+name = "app"
+
+[[bin]]
+name = "cli"
+version = "9.9.9"
+`
+	pyprojectTOML = `[build-system]
+requires = ["poetry-core"]
+
+[tool.poetry]
+name = "app"
+version = '2.0.0-rc.1'
+`
+	versionGo = `package version
+
+const (
+	// Version is the version of the app
+	Version = "v1.4.0"
+)
+`
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("unable to write %s: %s", name, err)
+		}
+	}
+	return dir
+}
+
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected Spec
+	}{
+		{"package.json", Spec{Path: "package.json"}},
+		{"charts/app/Chart.yaml:appVersion", Spec{Path: "charts/app/Chart.yaml", Field: "appVersion"}},
+		{`C:\app\VERSION`, Spec{Path: `C:\app\VERSION`}},
+	}
+
+	for _, tc := range tests {
+		s := ParseSpec(tc.spec)
+		if s != tc.expected {
+			t.Fatalf("expected spec %q to parse to %+v, but got %+v", tc.spec, tc.expected, s)
+		}
+
+		if s.String() != tc.spec {
+			t.Fatalf("expected spec %+v to print as %q, but got %q", s, tc.spec, s.String())
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"package.json":   packageJSON,
+		"Chart.yaml":     chartYAML,
+		"Cargo.toml":     cargoTOML,
+		"pyproject.toml": pyprojectTOML,
+		"bin.toml":       binTOML,
+		"version.go":     versionGo,
+		"VERSION":        "\n  1.0.0\n",
+		"pom.xml":        "<version>1.0.0</version>\n",
+	})
+
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{"package.json", "1.2.3"},
+		{"Chart.yaml", "0.4.1"},
+		{"Chart.yaml:appVersion", "1.2.3"},
+		{"Cargo.toml", "0.3.0"},
+		{"pyproject.toml", "2.0.0-rc.1"},
+		{"version.go", "v1.4.0"},
+		{"VERSION", "1.0.0"},
+	}
+
+	for _, tc := range tests {
+		v, err := Read(ParseSpec(filepath.Join(dir, tc.spec)))
+		if err != nil {
+			t.Fatalf("error reading %s: %s", tc.spec, err)
+		}
+
+		if v != tc.expected {
+			t.Fatalf("expected %s to hold version %s, but got %s", tc.spec, tc.expected, v)
+		}
+	}
+
+	if _, err := Read(ParseSpec(filepath.Join(dir, "Chart.yaml:kubeVersion"))); !errors.Is(err, ErrFieldNotFound) {
+		t.Fatalf("expected a missing field to fail with %q, but got: %v", ErrFieldNotFound, err)
+	}
+
+	if _, err := Read(ParseSpec(filepath.Join(dir, "bin.toml:package.version"))); !errors.Is(err, ErrFieldNotFound) {
+		t.Fatalf("expected a version in an array of tables to fail with %q, but got: %v", ErrFieldNotFound, err)
+	}
+
+	if _, err := Read(ParseSpec(filepath.Join(dir, "pom.xml"))); !errors.Is(err, ErrUnknownFormat) {
+		t.Fatalf("expected an unknown format to fail with %q, but got: %v", ErrUnknownFormat, err)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"Chart.yaml": chartYAML,
+		"Cargo.toml": cargoTOML,
+	})
+	if err := os.Chmod(filepath.Join(dir, "Cargo.toml"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Write([]Update{
+		{Spec: Spec{Path: filepath.Join(dir, "Chart.yaml")}, Version: "0.5.0"},
+		{Spec: Spec{Path: filepath.Join(dir, "Chart.yaml"), Field: "appVersion"}, Version: "1.3.0"},
+		{Spec: Spec{Path: filepath.Join(dir, "Cargo.toml")}, Version: "0.4.0"},
+	})
+	if err != nil {
+		t.Fatalf("error writing updates: %s", err)
+	}
+
+	expected := map[string]string{
+		"Chart.yaml": `apiVersion: v2
+name: app
+# the chart version
+version: 0.5.0
+appVersion: "1.3.0" # the app version
+`,
+		"Cargo.toml": `[package]
+name = "app"
+version = "0.4.0" # bumped by release
+
+[dependencies]
+serde = { version = "1.0" }
+`,
+	}
+
+	for name, content := range expected {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(b) != content {
+			t.Fatalf("expected %s to be\n%s\nbut got\n%s", name, content, b)
+		}
+	}
+
+	fi, err := os.Stat(filepath.Join(dir, "Cargo.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fi.Mode().Perm() != 0600 {
+		t.Fatalf("expected Cargo.toml to keep mode 0600, but got %o", fi.Mode().Perm())
+	}
+}
+
+func TestWriteAllOrNothing(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"package.json": packageJSON,
+		"VERSION":      "1.2.3\n",
+	})
+
+	err := Write([]Update{
+		{Spec: Spec{Path: filepath.Join(dir, "package.json")}, Version: "1.3.0"},
+		{Spec: Spec{Path: filepath.Join(dir, "VERSION"), Field: "version"}, Version: "1.3.0"},
+	})
+	if !errors.Is(err, ErrFieldNotFound) {
+		t.Fatalf("expected a missing field to fail with %q, but got: %v", ErrFieldNotFound, err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != packageJSON {
+		t.Fatalf("expected package.json to be left untouched, but got\n%s", b)
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected no staged files to be left behind, but got %d entries", len(entries))
+	}
+}
+
+func TestWriteRestoresOnFailure(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"package.json": packageJSON,
+		"Chart.yaml":   chartYAML,
+	})
+
+	// fail renaming the second file into place
+	renames := 0
+	rename = func(from, to string) error {
+		if filepath.Base(to) == "Chart.yaml" && renames == 1 {
+			renames++
+			return errors.New("rename failed")
+		}
+		renames++
+		return os.Rename(from, to)
+	}
+	defer func() { rename = os.Rename }()
+
+	err := Write([]Update{
+		{Spec: Spec{Path: filepath.Join(dir, "package.json")}, Version: "1.3.0"},
+		{Spec: Spec{Path: filepath.Join(dir, "Chart.yaml")}, Version: "0.5.0"},
+	})
+	if err == nil {
+		t.Fatalf("expected the failed rename to fail the write, but got no error")
+	}
+
+	for name, content := range map[string]string{"package.json": packageJSON, "Chart.yaml": chartYAML} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != content {
+			t.Fatalf("expected %s to be restored, but got\n%s", name, b)
+		}
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected no staged files or backups to be left behind, but got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,345 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultTimeout is the time requests to a registry may take when no HTTP
+// client is given
+const defaultTimeout = 30 * time.Second
+
+// Credentials are the username and password of a registry
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// configFile is the part of a docker config file holding credentials
+type configFile struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"auths"`
+}
+
+// DefaultConfigFile returns the path of the docker config file, in the
+// directory named by DOCKER_CONFIG or else in ~/.docker
+func DefaultConfigFile() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker", "config.json")
+}
+
+// ReadCredentials returns the credentials of a registry host from the auths
+// of a docker config file, or nil when there are none. Credential helpers
+// aren't supported.
+func ReadCredentials(path string, host string) (*Credentials, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var c configFile
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	key, ok := credentialsKey(c, host)
+	if !ok {
+		return nil, nil
+	}
+
+	a := c.Auths[key]
+	if a.Auth == "" {
+		return &Credentials{Username: a.Username, Password: a.Password}, nil
+	}
+
+	d, err := base64.StdEncoding.DecodeString(a.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid auth of %s: %w", path, key, err)
+	}
+
+	user, pass, ok := strings.Cut(string(d), ":")
+	if !ok {
+		return nil, fmt.Errorf("%s: invalid auth of %s", path, key)
+	}
+	return &Credentials{Username: user, Password: pass}, nil
+}
+
+// credentialsKey returns the key of the auths of a docker config file holding
+// the credentials of a registry host. A key naming the host exactly is
+// preferred, then the first key in sorted order naming the host as a URL or
+// by another of its names.
+func credentialsKey(c configFile, host string) (string, bool) {
+	exact := host
+	if host == dockerHub {
+		exact = dockerHubConfigKey
+	}
+	if _, ok := c.Auths[exact]; ok {
+		return exact, true
+	}
+
+	keys := make([]string, 0, len(c.Auths))
+	for key := range c.Auths {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if configHost(key) == configHost(host) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// configHost returns the registry host of a key of the auths of a docker
+// config file, which may be a URL. Docker Hub is known by several hosts.
+func configHost(key string) string {
+	if key == dockerHubConfigKey {
+		return dockerHub
+	}
+
+	h := strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	if i := strings.IndexByte(h, '/'); i >= 0 {
+		h = h[:i]
+	}
+
+	switch h {
+	case "docker.io", "index.docker.io":
+		return dockerHub
+	}
+	return h
+}
+
+// client makes requests to a registry, authorizing them when challenged
+type client struct {
+	http  *http.Client
+	repo  Repository
+	creds *Credentials
+	// plainHTTP reports whether the registry is accessed over http, in
+	// which case token servers may be too
+	plainHTTP bool
+	// authorization is the Authorization header of requests once the
+	// registry challenged a request
+	authorization string
+}
+
+func newClient(r Repository, opts *Options) (*client, error) {
+	path := opts.ConfigFile
+	if path == "" {
+		path = DefaultConfigFile()
+	}
+
+	creds, err := ReadCredentials(path, r.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &client{http: opts.Client, repo: r, creds: creds, plainHTTP: opts.PlainHTTP || isLocal(r.Host)}
+	if c.http == nil {
+		c.http = &http.Client{Timeout: defaultTimeout}
+	}
+	return c, nil
+}
+
+// get requests a URL, answering an authentication challenge once. The
+// caller closes the body of the response.
+func (c *client) get(u string) (*http.Response, error) {
+	resp, err := c.do(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized && c.authorization == "" && c.sameHost(u) {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		if c.authorization, err = c.authorize(challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = c.do(u); err != nil {
+			return nil, err
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %w", c.repo, ErrUnauthorized)
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %w", c.repo, ErrNotFound)
+	}
+	resp.Body.Close()
+	return nil, fmt.Errorf("%s: unexpected response %s", c.repo, resp.Status)
+}
+
+// do requests a URL with the authorization, if any. The authorization is only
+// sent to the registry host, never to another host a page links to.
+func (c *client) do(u string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if c.authorization != "" && c.sameHost(u) {
+		req.Header.Set("Authorization", c.authorization)
+	}
+	return c.http.Do(req)
+}
+
+// sameHost reports whether a URL is on the registry host
+func (c *client) sameHost(u string) bool {
+	p, err := url.Parse(u)
+	return err == nil && p.Host == c.repo.Host
+}
+
+// authorize returns the Authorization header answering an authentication
+// challenge, see https://distribution.github.io/distribution/spec/auth/token/
+func (c *client) authorize(challenge string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.creds == nil {
+			return "", fmt.Errorf("%s: %w: no credentials for %s", c.repo, ErrUnauthorized, c.repo.Host)
+		}
+		return "Basic " + basicAuth(c.creds), nil
+
+	case "bearer":
+		token, err := c.token(params)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	}
+	return "", fmt.Errorf("%s: %w: unsupported challenge %q", c.repo, ErrUnauthorized, challenge)
+}
+
+// tokenResponse is the response of a token server
+type tokenResponse struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+}
+
+// token requests a bearer token from the token server of a challenge, with
+// the credentials if there are any
+func (c *client) token(params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("%s: invalid token realm %q", c.repo, params["realm"])
+	}
+
+	// credentials are only sent in the clear to registries reached that way
+	if realm.Scheme != "https" && !(realm.Scheme == "http" && c.plainHTTP) {
+		return "", fmt.Errorf("%s: %w: token realm %q isn't https", c.repo, ErrUnauthorized, params["realm"])
+	}
+
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + c.repo.Name + ":pull"
+	}
+
+	q := realm.Query()
+	q.Set("scope", scope)
+	if s := params["service"]; s != "" {
+		q.Set("service", s)
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.creds != nil {
+		req.SetBasicAuth(c.creds.Username, c.creds.Password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %w: token server responded %s", c.repo, ErrUnauthorized, resp.Status)
+	}
+
+	var t tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return "", fmt.Errorf("%s: invalid token response: %w", c.repo, err)
+	}
+
+	if t.Token == "" {
+		t.Token = t.AccessToken
+	}
+	if t.Token == "" {
+		return "", fmt.Errorf("%s: token server returned no token", c.repo)
+	}
+	return t.Token, nil
+}
+
+// parseChallenge parses the scheme and parameters of a WWW-Authenticate
+// header, e.g. Bearer realm="https://auth.example.com/token",service="registry"
+func parseChallenge(h string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(h), " ")
+	params := make(map[string]string)
+
+	for rest = strings.TrimSpace(rest); rest != ""; {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if strings.HasPrefix(value, `"`) {
+			// quoted values may contain commas
+			end := strings.IndexByte(value[1:], '"')
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			rest = value[end+2:]
+		} else {
+			v, r, _ := strings.Cut(value, ",")
+			params[key] = strings.TrimSpace(v)
+			rest = r
+		}
+		rest = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(rest), ","))
+	}
+	return scheme, params
+}
+
+// basicAuth returns the encoded credentials of a Basic Authorization header
+func basicAuth(c *Credentials) string {
+	return base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.Password))
+}
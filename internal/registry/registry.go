@@ -0,0 +1,192 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrUnauthorized is returned when the registry refuses the credentials,
+	// or requires credentials and none are configured
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotFound is returned when the registry has no such repository
+	ErrNotFound = errors.New("repository not found")
+)
+
+const (
+	// dockerHub is the registry host of Docker Hub repositories
+	dockerHub = "registry-1.docker.io"
+	// dockerHubConfigKey is the key of Docker Hub credentials in a docker
+	// config file
+	dockerHubConfigKey = "https://index.docker.io/v1/"
+)
+
+// maxPages is the number of pages of a tag list that are fetched before the
+// listing is given up on
+const maxPages = 1000
+
+// namePattern is the repository name grammar of the OCI Distribution
+// Specification
+var namePattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+
+// Repository is a repository of a container registry
+type Repository struct {
+	// Host is the registry host, e.g. ghcr.io or localhost:5000
+	Host string
+	// Name is the repository name, e.g. org/app
+	Name string
+}
+
+// ParseRepository parses a repository reference such as ghcr.io/org/app or
+// localhost:5000/app. References without a registry host, such as
+// library/alpine or alpine, are Docker Hub repositories.
+func ParseRepository(s string) (Repository, error) {
+	var r Repository
+	if strings.ContainsAny(s, "@") {
+		return r, fmt.Errorf("invalid repository %q: digests are not allowed", s)
+	}
+
+	host, name := "", s
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		first := s[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			host, name = first, s[i+1:]
+		}
+	}
+
+	switch host {
+	case "", "docker.io", "index.docker.io":
+		host = dockerHub
+		if !strings.Contains(name, "/") {
+			name = "library/" + name
+		}
+	}
+
+	if strings.Contains(name, ":") {
+		return r, fmt.Errorf("invalid repository %q: tags are not allowed", s)
+	}
+	if !namePattern.MatchString(name) {
+		return r, fmt.Errorf("invalid repository %q: invalid name %q", s, name)
+	}
+
+	r.Host, r.Name = host, name
+	return r, nil
+}
+
+// String returns the repository reference
+func (r Repository) String() string {
+	return r.Host + "/" + r.Name
+}
+
+// Options configures how a registry is accessed
+type Options struct {
+	// Client is the HTTP client requests are made with, a client with a
+	// timeout of 30 seconds when nil
+	Client *http.Client
+	// ConfigFile is the docker config file credentials are read from,
+	// DefaultConfigFile when empty
+	ConfigFile string
+	// PlainHTTP accesses the registry over http instead of https. It's
+	// implied for registries on localhost.
+	PlainHTTP bool
+}
+
+// tagList is the response of the tag list endpoint
+type tagList struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// Tags returns the tags of a repository of a container registry, listed with
+// the OCI Distribution API. Credentials for the registry are taken from the
+// docker config file.
+func Tags(repo string, opts *Options) ([]string, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	r, err := ParseRepository(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := newClient(r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := "https"
+	if c.plainHTTP {
+		scheme = "http"
+	}
+
+	u := &url.URL{Scheme: scheme, Host: r.Host, Path: "/v2/" + r.Name + "/tags/list"}
+	tags := make([]string, 0)
+	visited := make(map[string]bool)
+	for u != nil {
+		// a registry linking back to a page, or without end, never finishes
+		if visited[u.String()] {
+			return nil, fmt.Errorf("%s: tag list links back to %s", r, u)
+		}
+		if len(visited) == maxPages {
+			return nil, fmt.Errorf("%s: tag list has more than %d pages", r, maxPages)
+		}
+		visited[u.String()] = true
+
+		resp, err := c.get(u.String())
+		if err != nil {
+			return nil, err
+		}
+
+		var l tagList
+		err = json.NewDecoder(resp.Body).Decode(&l)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid tag list: %w", r, err)
+		}
+		tags = append(tags, l.Tags...)
+
+		if u, err = next(u, resp.Header.Get("Link")); err != nil {
+			return nil, fmt.Errorf("%s: %w", r, err)
+		}
+	}
+	return tags, nil
+}
+
+// next returns the URL of the next page of a paginated response from its
+// Link header, or nil when it's the last page
+func next(u *url.URL, link string) (*url.URL, error) {
+	for _, l := range strings.Split(link, ",") {
+		l = strings.TrimSpace(l)
+		if !strings.HasPrefix(l, "<") || !strings.Contains(l, `rel="next"`) {
+			continue
+		}
+
+		end := strings.IndexByte(l, '>')
+		if end < 0 {
+			return nil, fmt.Errorf("invalid link header %q", link)
+		}
+
+		ref, err := url.Parse(l[1:end])
+		if err != nil {
+			return nil, fmt.Errorf("invalid link header %q: %w", link, err)
+		}
+		return u.ResolveReference(ref), nil
+	}
+	return nil, nil
+}
+
+// isLocal reports whether a registry host is on the local machine
+func isLocal(host string) bool {
+	h := host
+	if i := strings.LastIndexByte(host, ':'); i >= 0 && !strings.HasSuffix(host, "]") {
+		h = host[:i]
+	}
+	h = strings.Trim(h, "[]")
+	return h == "localhost" || h == "127.0.0.1" || h == "::1"
+}
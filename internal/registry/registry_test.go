@@ -0,0 +1,312 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testRegistry is an in-process stand-in for a registry serving the tag list
+// of a repository in pages of two tags
+type testRegistry struct {
+	*httptest.Server
+	repo string
+	tags []string
+	// auth is the authentication the registry challenges for: none, basic
+	// or bearer
+	auth     string
+	username string
+	password string
+}
+
+func newTestRegistry(t *testing.T, auth string, repo string, tags ...string) *testRegistry {
+	r := &testRegistry{repo: repo, tags: tags, auth: auth, username: "user", password: "secret"}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *testRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if u, p, ok := req.BasicAuth(); !ok || u != r.username || p != r.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if req.URL.Query().Get("scope") != "repository:"+r.repo+":pull" || req.URL.Query().Get("service") != "test" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"token": "t0ken"})
+		return
+	}
+
+	switch r.auth {
+	case "basic":
+		if u, p, ok := req.BasicAuth(); !ok || u != r.username || p != r.password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	case "bearer":
+		if req.Header.Get("Authorization") != "Bearer t0ken" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:%s:pull"`, r.URL, r.repo))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+
+	if req.URL.Path != "/v2/"+r.repo+"/tags/list" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	start := 0
+	if last := req.URL.Query().Get("last"); last != "" {
+		for i, tag := range r.tags {
+			if tag == last {
+				start = i + 1
+			}
+		}
+	}
+
+	end := start + 2
+	if end < len(r.tags) {
+		w.Header().Set("Link", fmt.Sprintf(`</v2/%s/tags/list?n=2&last=%s>; rel="next"`, r.repo, r.tags[end-1]))
+	} else {
+		end = len(r.tags)
+	}
+	json.NewEncoder(w).Encode(tagList{Name: r.repo, Tags: r.tags[start:end]})
+}
+
+// host returns the host of the registry
+func (r *testRegistry) host() string {
+	return strings.TrimPrefix(r.URL, "http://")
+}
+
+// writeConfig writes a docker config file with credentials for a host
+func writeConfig(t *testing.T, host string, username string, password string) string {
+	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	b, _ := json.Marshal(map[string]interface{}{
+		"auths": map[string]interface{}{
+			host: map[string]string{"auth": auth},
+		},
+	})
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		t.Fatal(err.Error())
+	}
+	return path
+}
+
+// TestTags verifies tags are listed across pages with each kind of auth
+func TestTags(t *testing.T) {
+	tags := []string{"1.0.0", "1.1.0", "latest", "v1.2.0-rc.1", "1.2.0"}
+
+	for _, auth := range []string{"none", "basic", "bearer"} {
+		r := newTestRegistry(t, auth, "org/app", tags...)
+		config := writeConfig(t, r.host(), r.username, r.password)
+
+		listed, err := Tags(r.host()+"/org/app", &Options{ConfigFile: config})
+		if err != nil {
+			t.Fatalf("%s auth: %s", auth, err.Error())
+		}
+
+		if strings.Join(listed, " ") != strings.Join(tags, " ") {
+			t.Fatalf("%s auth: expected tags %v, found %v", auth, tags, listed)
+		}
+	}
+}
+
+// TestTagsErrors verifies missing repositories and refused credentials are
+// reported
+func TestTagsErrors(t *testing.T) {
+	r := newTestRegistry(t, "basic", "org/app", "1.0.0")
+
+	none := filepath.Join(t.TempDir(), "config.json")
+	if _, err := Tags(r.host()+"/org/app", &Options{ConfigFile: none}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected no credentials to be unauthorized, found %v", err)
+	}
+
+	wrong := writeConfig(t, "http://"+r.host()+"/v2/", r.username, "wrong")
+	if _, err := Tags(r.host()+"/org/app", &Options{ConfigFile: wrong}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected wrong credentials to be unauthorized, found %v", err)
+	}
+
+	config := writeConfig(t, r.host(), r.username, r.password)
+	if _, err := Tags(r.host()+"/org/other", &Options{ConfigFile: config}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected repository not to be found, found %v", err)
+	}
+
+	b := newTestRegistry(t, "bearer", "org/app", "1.0.0")
+	if _, err := Tags(b.host()+"/org/app", &Options{ConfigFile: none}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected no credentials to be unauthorized, found %v", err)
+	}
+}
+
+// TestTagsOtherHost verifies the authorization isn't sent to another host a
+// page links to
+func TestTagsOtherHost(t *testing.T) {
+	var leaked string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		leaked = req.Header.Get("Authorization")
+		json.NewEncoder(w).Encode(tagList{Name: "org/app", Tags: []string{"2.0.0"}})
+	}))
+	defer other.Close()
+
+	r := newTestRegistry(t, "basic", "org/app")
+	r.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if _, _, ok := req.BasicAuth(); !ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/v2/org/app/tags/list?last=1.0.0>; rel="next"`, other.URL))
+		json.NewEncoder(w).Encode(tagList{Name: "org/app", Tags: []string{"1.0.0"}})
+	})
+	config := writeConfig(t, r.host(), r.username, r.password)
+
+	listed, err := Tags(r.host()+"/org/app", &Options{ConfigFile: config})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if strings.Join(listed, " ") != "1.0.0 2.0.0" {
+		t.Fatalf("expected tags [1.0.0 2.0.0], found %v", listed)
+	}
+
+	if leaked != "" {
+		t.Fatalf("expected no authorization to be sent to another host, found %q", leaked)
+	}
+}
+
+// TestTagsPageLoop verifies a tag list linking back to a page is reported
+func TestTagsPageLoop(t *testing.T) {
+	r := newTestRegistry(t, "none", "org/app")
+	r.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Link", `</v2/org/app/tags/list?last=1.0.0>; rel="next"`)
+		json.NewEncoder(w).Encode(tagList{Name: "org/app", Tags: []string{"1.0.0"}})
+	})
+
+	_, err := Tags(r.host()+"/org/app", &Options{ConfigFile: filepath.Join(t.TempDir(), "config.json")})
+	if err == nil || !strings.Contains(err.Error(), "links back") {
+		t.Fatalf("expected a tag list linking back to be reported, found %v", err)
+	}
+}
+
+// TestTokenRealm verifies credentials aren't sent to a plain http token
+// server of a registry reached over https
+func TestTokenRealm(t *testing.T) {
+	c := &client{
+		http:  &http.Client{},
+		repo:  Repository{Host: "registry.example.com", Name: "org/app"},
+		creds: &Credentials{Username: "user", Password: "secret"},
+	}
+
+	for _, realm := range []string{"http://auth.example.com/token", "ftp://auth.example.com/token"} {
+		if _, err := c.token(map[string]string{"realm": realm}); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected token realm %s to be refused, found %v", realm, err)
+		}
+	}
+}
+
+// TestDefaultClient verifies requests time out when no client is given
+func TestDefaultClient(t *testing.T) {
+	c, err := newClient(Repository{Host: "localhost", Name: "app"}, &Options{ConfigFile: filepath.Join(t.TempDir(), "config.json")})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if c.http.Timeout != defaultTimeout {
+		t.Fatalf("expected a timeout of %s, found %s", defaultTimeout, c.http.Timeout)
+	}
+}
+
+// TestParseRepository verifies registry hosts are told from repository names
+func TestParseRepository(t *testing.T) {
+	tests := []struct {
+		ref   string
+		host  string
+		name  string
+		valid bool
+	}{
+		{"ghcr.io/org/app", "ghcr.io", "org/app", true},
+		{"localhost:5000/app", "localhost:5000", "app", true},
+		{"localhost/app", "localhost", "app", true},
+		{"org/app", dockerHub, "org/app", true},
+		{"alpine", dockerHub, "library/alpine", true},
+		{"docker.io/alpine", dockerHub, "library/alpine", true},
+		{"ghcr.io/org/app:1.0.0", "", "", false},
+		{"ghcr.io/org/app@sha256:abc", "", "", false},
+		{"ghcr.io/Org/app", "", "", false},
+	}
+
+	for _, tc := range tests {
+		r, err := ParseRepository(tc.ref)
+		if !tc.valid {
+			if err == nil {
+				t.Fatalf("expected repository '%s' to be invalid", tc.ref)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if r.Host != tc.host || r.Name != tc.name {
+			t.Fatalf("expected repository '%s/%s', found '%s'", tc.host, tc.name, r)
+		}
+	}
+}
+
+// TestReadCredentials verifies credentials are found by registry host
+func TestReadCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := `{
+  "auths": {
+    "docker.io": {"username": "other", "password": "other"},
+    "https://index.docker.io/v1/": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("hub:pass")) + `"},
+    "index.docker.io": {"username": "other", "password": "other"},
+    "https://quay.io": {"username": "url", "password": "url"},
+    "quay.io/": {"username": "slash", "password": "slash"},
+    "ghcr.io": {"username": "gh", "password": "token"},
+    "https://ghcr.io": {"username": "url", "password": "url"}
+  },
+  "credsStore": "desktop"
+}`
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tests := []struct {
+		host     string
+		expected *Credentials
+	}{
+		{dockerHub, &Credentials{Username: "hub", Password: "pass"}},
+		{"ghcr.io", &Credentials{Username: "gh", Password: "token"}},
+		{"quay.io", &Credentials{Username: "url", Password: "url"}},
+		{"gcr.io", nil},
+	}
+
+	// the credentials are the same however the keys are ordered
+	for i := 0; i < 10; i++ {
+		for _, tc := range tests {
+			c, err := ReadCredentials(path, tc.host)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			if (c == nil) != (tc.expected == nil) || (c != nil && *c != *tc.expected) {
+				t.Fatalf("expected credentials %+v for %s, found %+v", tc.expected, tc.host, c)
+			}
+		}
+	}
+}
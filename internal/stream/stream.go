@@ -0,0 +1,122 @@
+package stream
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// maxToken is the longest line or value that can be read
+const maxToken = 1024 * 1024
+
+// Scan reads the values of a list of versions, calling fn with each value
+// as it's read so the list is never held in memory as a whole. The list is
+// either a JSON array of strings, such as the output of npm view pkg versions
+// --json, a single JSON string, or values separated by whitespace.
+//
+// When an extractor is given, the values are the matches of the extractor in
+// each line, or in each element of a JSON array, instead. The value of a match
+// is its first capture group when the extractor has one, and lines without a
+// match are skipped, e.g. refs/tags/(v[^^]+)$ extracts the tags from the
+// output of git ls-remote --tags.
+func Scan(r io.Reader, extract *regexp.Regexp, fn func(string) error) error {
+	br := bufio.NewReader(r)
+	first, err := peek(br)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	emit := func(s string) error {
+		if extract == nil {
+			return fn(s)
+		}
+		return extractAll(extract, s, fn)
+	}
+
+	switch first {
+	case '[', '"':
+		return scanJSON(br, emit)
+	}
+
+	s := bufio.NewScanner(br)
+	s.Buffer(make([]byte, 0, 64*1024), maxToken)
+	if extract == nil {
+		s.Split(bufio.ScanWords)
+	}
+	for s.Scan() {
+		if err := emit(s.Text()); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
+
+// peek returns the first byte that isn't whitespace without consuming it
+func peek(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			br.ReadByte()
+			continue
+		}
+		return b[0], nil
+	}
+}
+
+// scanJSON reads the strings of a JSON array element by element, or a single
+// JSON string
+func scanJSON(r io.Reader, fn func(string) error) error {
+	d := json.NewDecoder(r)
+	t, err := d.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON list: %w", err)
+	}
+
+	if s, ok := t.(string); ok {
+		return fn(s)
+	}
+
+	for d.More() {
+		var s string
+		if err := d.Decode(&s); err != nil {
+			return fmt.Errorf("invalid JSON list: %w", err)
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
+
+	if _, err := d.Token(); err != nil {
+		return fmt.Errorf("invalid JSON list: %w", err)
+	}
+	return nil
+}
+
+// extractAll calls fn with the value of each match of an extractor in s
+func extractAll(extract *regexp.Regexp, s string, fn func(string) error) error {
+	for _, m := range extract.FindAllStringSubmatch(s, -1) {
+		v := m[0]
+		if len(m) > 1 {
+			v = m[1]
+		}
+
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package stream
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func scanAll(in string, extract *regexp.Regexp) ([]string, error) {
+	values := make([]string, 0)
+	err := Scan(strings.NewReader(in), extract, func(v string) error {
+		values = append(values, v)
+		return nil
+	})
+	return values, err
+}
+
+func TestScan(t *testing.T) {
+	tags := regexp.MustCompile(`refs/tags/([^^]+)$`)
+
+	tests := []struct {
+		in       string
+		extract  *regexp.Regexp
+		expected []string
+	}{
+		{"", nil, []string{}},
+		{"1.0.0\n1.2.3\n", nil, []string{"1.0.0", "1.2.3"}},
+		{"  1.0.0 1.2.3\t2.0.0-rc.1\r\n\n", nil, []string{"1.0.0", "1.2.3", "2.0.0-rc.1"}},
+		{`["1.0.0", "1.2.3",
+  "2.0.0"]`, nil, []string{"1.0.0", "1.2.3", "2.0.0"}},
+		{"\n  \"1.2.3\"\n", nil, []string{"1.2.3"}},
+		{"[]", nil, []string{}},
+		{"a1b2\trefs/tags/v1.0.0\nc3d4\trefs/tags/v1.0.0^{}\ne5f6\trefs/tags/v1.1.0\n", tags, []string{"v1.0.0", "v1.1.0"}},
+		{"version: 1.2.3, previous: 1.2.2\n", regexp.MustCompile(`[0-9]+\.[0-9]+\.[0-9]+`), []string{"1.2.3", "1.2.2"}},
+		{`["release-1.0.0", "nightly", "release-1.1.0"]`, regexp.MustCompile(`^release-(.*)$`), []string{"1.0.0", "1.1.0"}},
+	}
+
+	for _, tc := range tests {
+		values, err := scanAll(tc.in, tc.extract)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %s", tc.in, err)
+		}
+		if !reflect.DeepEqual(values, tc.expected) {
+			t.Fatalf("expected %v for %q, but got %v", tc.expected, tc.in, values)
+		}
+	}
+}
+
+func TestScanInvalid(t *testing.T) {
+	tests := []string{
+		`["1.0.0", 2]`,
+		`["1.0.0"`,
+		`[{"version": "1.0.0"}]`,
+	}
+
+	for _, in := range tests {
+		if _, err := scanAll(in, nil); err == nil {
+			t.Fatalf("expected an error for %q, but got none", in)
+		}
+	}
+}
+
+func TestScanStops(t *testing.T) {
+	stop := errors.New("stop")
+	count := 0
+	err := Scan(strings.NewReader("1.0.0 1.1.0 1.2.0"), nil, func(v string) error {
+		count++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected the error of the callback, but got %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 value before stopping, but got %d", count)
+	}
+}
@@ -8,6 +8,6 @@ import (
 
 func main() {
 	if err := cli.New().Execute(); err != nil {
-		log.Fatal("error during command execution: %v", err)
+		log.Fatalf("error during command execution: %v", err)
 	}
 }
@@ -0,0 +1,311 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoIncrement is returned when an increment wouldn't change a version, such
+// as a second increment of a date based version on the same date
+var ErrNoIncrement = errors.New("increment doesn't change the version")
+
+// calverToken is a component of a calendar version format, see https://calver.org
+type calverToken struct {
+	name string
+	// pad is the width the value is zero padded to
+	pad int
+	// width is the fixed number of digits of the value, if any, which allows
+	// the token to be followed by another without a separator
+	width int
+	// date reports whether the value follows the date instead of counting
+	date bool
+}
+
+// calverTokens are the tokens of calendar version formats, longest first so
+// they can be matched greedily
+var calverTokens = []calverToken{
+	{name: "MAJOR"},
+	{name: "MINOR"},
+	{name: "MICRO"},
+	{name: "YYYY", pad: 4, width: 4, date: true},
+	{name: "YY", date: true},
+	{name: "0Y", pad: 2, date: true},
+	{name: "MM", date: true},
+	{name: "0M", pad: 2, width: 2, date: true},
+	{name: "WW", date: true},
+	{name: "0W", pad: 2, width: 2, date: true},
+	{name: "DD", date: true},
+	{name: "0D", pad: 2, width: 2, date: true},
+}
+
+// CalVer is a calendar versioning scheme with a format such as YYYY.MM.MICRO
+// or YY.0M.DD
+type CalVer struct {
+	format string
+	tokens []calverToken
+	// seps are the separators following each token
+	seps []string
+	re   *regexp.Regexp
+	// Date is the date increments roll the date components to. The current
+	// date is used when zero.
+	Date time.Time
+}
+
+// calverVersion is a parsed calendar version
+type calverVersion struct {
+	scheme *CalVer
+	raw    string
+	// values are the component values, with years in full
+	values []int
+}
+
+// NewCalVer returns a calendar versioning scheme for a format made of the
+// tokens YYYY, YY, 0Y, MM, 0M, WW, 0W, DD, 0D, MAJOR, MINOR and MICRO
+// separated by dots, dashes or underscores. Only the tokens of a fixed width,
+// YYYY, 0M, 0W and 0D, may be followed by another without a separator, as in
+// YYYY0M0D.
+func NewCalVer(format string) (*CalVer, error) {
+	c := &CalVer{format: format}
+	pattern := "^"
+	for rest := format; rest != ""; {
+		matched := false
+		for _, t := range calverTokens {
+			if strings.HasPrefix(rest, t.name) {
+				if n := len(c.tokens); n > 0 && c.seps[n-1] == "" {
+					prev := c.tokens[n-1]
+					if prev.width == 0 {
+						return nil, fmt.Errorf("invalid calver format %q: %s needs a separator before %s", format, prev.name, t.name)
+					}
+					pattern = strings.TrimSuffix(pattern, `([0-9]+)`) + fmt.Sprintf(`([0-9]{%d})`, prev.width)
+				}
+
+				c.tokens = append(c.tokens, t)
+				c.seps = append(c.seps, "")
+				pattern += `([0-9]+)`
+				rest = rest[len(t.name):]
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		if len(c.tokens) == 0 || c.seps[len(c.seps)-1] != "" || !strings.ContainsAny(rest[:1], ".-_") {
+			return nil, fmt.Errorf("invalid calver format %q at %q", format, rest)
+		}
+		c.seps[len(c.seps)-1] = rest[:1]
+		pattern += regexp.QuoteMeta(rest[:1])
+		rest = rest[1:]
+	}
+
+	if len(c.tokens) == 0 || c.seps[len(c.seps)-1] != "" {
+		return nil, fmt.Errorf("invalid calver format %q", format)
+	}
+
+	c.re = regexp.MustCompile(pattern + "$")
+	return c, nil
+}
+
+// Name returns the name of the scheme
+func (c *CalVer) Name() string {
+	return "calver"
+}
+
+// Format returns the format of the scheme
+func (c *CalVer) Format() string {
+	return c.format
+}
+
+// Parse parses a raw version value in the format of the scheme. Values don't
+// need to be zero padded, so 2022.9.1 and 2022.09.1 are both valid versions
+// of YYYY.0M.MICRO.
+func (c *CalVer) Parse(in string) (Ordered, error) {
+	m := c.re.FindStringSubmatch(in)
+	if m == nil {
+		return nil, fmt.Errorf("invalid version %q: doesn't match calver format %s", in, c.format)
+	}
+
+	v := &calverVersion{scheme: c, raw: in, values: make([]int, len(c.tokens))}
+	for i, t := range c.tokens {
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q: %s out of range", in, t.name)
+		}
+
+		switch t.name {
+		case "YYYY":
+			if n < 1000 {
+				return nil, fmt.Errorf("invalid version %q: YYYY must have four digits", in)
+			}
+		case "YY", "0Y":
+			if n >= 1000 {
+				return nil, fmt.Errorf("invalid version %q: %s must have at most three digits", in, t.name)
+			}
+			n += 2000
+		case "MM", "0M":
+			if n < 1 || n > 12 {
+				return nil, fmt.Errorf("invalid version %q: month out of range", in)
+			}
+		case "WW", "0W":
+			if n < 1 || n > 53 {
+				return nil, fmt.Errorf("invalid version %q: week out of range", in)
+			}
+		case "DD", "0D":
+			if n < 1 || n > 31 {
+				return nil, fmt.Errorf("invalid version %q: day out of range", in)
+			}
+		}
+		v.values[i] = n
+	}
+	return v, nil
+}
+
+// Increment returns the version with its date components rolled to the date
+// of the scheme. A patch increment increments MICRO, or resets it when the
+// date changed. A minor increment increments MINOR and a major increment
+// MAJOR, resetting the counters after them. When the format lacks the
+// counter, the next smaller one is incremented instead, or reset like MICRO
+// when the date changed. Formats with a week use the year of the ISO week.
+func (c *CalVer) Increment(in string, rt ReleaseType, ident string) (string, error) {
+	o, err := c.Parse(in)
+	if err != nil {
+		return "", err
+	}
+	v := o.(*calverVersion)
+
+	counters := map[ReleaseType][]string{
+		Major: {"MAJOR", "MINOR", "MICRO"},
+		Minor: {"MINOR", "MICRO"},
+		Patch: {"MICRO"},
+	}
+	order, ok := counters[rt]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", rt, ErrUnsupportedReleaseType)
+	}
+
+	date := c.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	year, week := date.ISOWeek()
+	if c.index("WW") < 0 && c.index("0W") < 0 {
+		year = date.Year()
+	}
+
+	nv := &calverVersion{scheme: c, values: make([]int, len(v.values))}
+	copy(nv.values, v.values)
+	rolled := false
+	for i, t := range c.tokens {
+		if !t.date {
+			continue
+		}
+
+		switch t.name {
+		case "YYYY", "YY", "0Y":
+			nv.values[i] = year
+		case "MM", "0M":
+			nv.values[i] = int(date.Month())
+		case "WW", "0W":
+			nv.values[i] = week
+		case "DD", "0D":
+			nv.values[i] = date.Day()
+		}
+		rolled = rolled || nv.values[i] != v.values[i]
+	}
+
+	// the first counter of the release type the format has
+	target := -1
+	for _, name := range order {
+		if target = c.index(name); target >= 0 {
+			break
+		}
+	}
+
+	if target >= 0 {
+		// a counter standing in for a missing one rolls with the date too
+		if rolled && (rt == Patch || c.tokens[target].name != order[0]) {
+			nv.values[target] = 0
+		} else {
+			nv.values[target]++
+		}
+
+		for _, name := range counters[Major] {
+			if i := c.index(name); i >= 0 && rank(name) > rank(c.tokens[target].name) {
+				nv.values[i] = 0
+			}
+		}
+	}
+
+	switch cmp := nv.Compare(v); {
+	case cmp == 0:
+		return "", fmt.Errorf("%s on %s: %w", in, date.Format("2006-01-02"), ErrNoIncrement)
+	case cmp < 0:
+		return "", fmt.Errorf("%s is later than %s", in, date.Format("2006-01-02"))
+	}
+	return nv.String(), nil
+}
+
+// index returns the position of a token in the format, or -1 if it's missing
+func (c *CalVer) index(name string) int {
+	for i, t := range c.tokens {
+		if t.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// rank orders the counters from most to least significant
+func rank(name string) int {
+	return map[string]int{"MAJOR": 0, "MINOR": 1, "MICRO": 2}[name]
+}
+
+// String returns the version in the format of its scheme, zero padded
+func (v *calverVersion) String() string {
+	var b strings.Builder
+	for i, t := range v.scheme.tokens {
+		n := v.values[i]
+		if t.name == "YY" || t.name == "0Y" {
+			n -= 2000
+		}
+		fmt.Fprintf(&b, "%0*d%s", t.pad, n, v.scheme.seps[i])
+	}
+	return b.String()
+}
+
+// Original returns the raw value the version was parsed from
+func (v *calverVersion) Original() string {
+	if v.raw == "" {
+		return v.String()
+	}
+	return v.raw
+}
+
+// Compare compares the components of the versions in order
+func (v *calverVersion) Compare(o Ordered) int {
+	ov, ok := o.(*calverVersion)
+	if !ok {
+		return strings.Compare(v.String(), o.String())
+	}
+
+	for i := range v.values {
+		if i >= len(ov.values) {
+			return 1
+		}
+		switch {
+		case v.values[i] < ov.values[i]:
+			return -1
+		case v.values[i] > ov.values[i]:
+			return 1
+		}
+	}
+	if len(v.values) < len(ov.values) {
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,188 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewCalVer(t *testing.T) {
+	for _, format := range []string{"YYYY.MM.MICRO", "YY.0M.DD", "YYYY.0M.0D-MICRO", "YYYY.0W_MINOR.MICRO", "0Y.MM", "YYYYMM", "YYYY0M0D.MICRO"} {
+		if _, err := NewCalVer(format); err != nil {
+			t.Fatalf("error for format %s: %s", format, err)
+		}
+	}
+
+	for _, format := range []string{"", "YYYY..MM", "YYYY.MM.", ".YYYY", "YYYY.MONTH", "YYYY.MMDD", "0Y0M", "MAJORMINOR"} {
+		if _, err := NewCalVer(format); err == nil {
+			t.Fatalf("expected format %q to be invalid", format)
+		}
+	}
+}
+
+func TestCalVerParse(t *testing.T) {
+	tests := []struct {
+		format     string
+		version    string
+		normalized string
+		valid      bool
+	}{
+		{"YYYY.0M.MICRO", "2022.09.1", "2022.09.1", true},
+		{"YYYY.0M.MICRO", "2022.9.1", "2022.09.1", true},
+		{"YY.0M.DD", "22.09.19", "22.09.19", true},
+		{"0Y.0M", "6.1", "06.01", true},
+		{"YYYY.0M.MICRO", "2022.13.1", "", false},
+		{"YYYY.0M.MICRO", "22.09.1", "", false},
+		{"YY.0M.DD", "2022.09.19", "", false},
+		{"YY.0M.DD", "22.09.32", "", false},
+		{"YYYY.0M.MICRO", "2022.09", "", false},
+		{"YYYY.0M.MICRO", "v2022.09.1", "", false},
+		{"YYYY0M0D", "20240115", "20240115", true},
+		{"YYYY0M0D.MICRO", "20240115.2", "20240115.2", true},
+		{"YYYY0M0D", "202401", "", false},
+		{"YYYY0M0D", "20241301", "", false},
+	}
+
+	for _, tc := range tests {
+		c, err := NewCalVer(tc.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		v, err := c.Parse(tc.version)
+		if !tc.valid {
+			if err == nil {
+				t.Fatalf("expected version %s to be invalid in format %s", tc.version, tc.format)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("error for version %s in format %s: %s", tc.version, tc.format, err)
+		}
+
+		if v.String() != tc.normalized || v.Original() != tc.version {
+			t.Fatalf("expected version %s to normalize to %s, but got %s", tc.version, tc.normalized, v.String())
+		}
+	}
+}
+
+func TestCalVerIncrement(t *testing.T) {
+	date := time.Date(2022, time.September, 19, 9, 18, 48, 0, time.UTC)
+
+	tests := []struct {
+		format   string
+		version  string
+		rt       ReleaseType
+		expected string
+		err      error
+	}{
+		{"YYYY.0M.MICRO", "2022.08.3", Patch, "2022.09.0", nil},
+		{"YYYY.0M.MICRO", "2022.09.3", Patch, "2022.09.4", nil},
+		{"YYYY.0M.MICRO", "2022.9.3", Minor, "2022.09.4", nil},
+		{"YY.0M.DD", "22.09.01", Patch, "22.09.19", nil},
+		{"YY.0M.DD", "22.09.19", Patch, "", ErrNoIncrement},
+		{"YYYY.MINOR.MICRO", "2022.3.7", Minor, "2022.4.0", nil},
+		{"YYYY.MINOR.MICRO", "2021.3.7", Patch, "2022.3.0", nil},
+		{"MAJOR.YYYY.0M.MICRO", "4.2022.09.2", Major, "5.2022.09.0", nil},
+		{"YYYY.0W.MICRO", "2022.37.1", Patch, "2022.38.0", nil},
+		{"YYYY.0M.MICRO", "2022.08.3", Major, "2022.09.0", nil},
+		{"YYYY.0M.MICRO", "2022.09.3", Major, "2022.09.4", nil},
+		{"YYYY.MINOR.MICRO", "2021.3.7", Major, "2022.0.0", nil},
+		{"YYYY.0M.MICRO", "2022.09.3", PreRelease, "", ErrUnsupportedReleaseType},
+		{"YYYY0M0D", "20220915", Patch, "20220919", nil},
+		{"YYYY0M0D.MICRO", "20220919.2", Patch, "20220919.3", nil},
+	}
+
+	for _, tc := range tests {
+		c, err := NewCalVer(tc.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Date = date
+
+		v, err := c.Increment(tc.version, tc.rt, "")
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %s %s increment to fail with %q, but got: %v", tc.version, tc.rt, tc.err, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("error for %s %s increment: %s", tc.version, tc.rt, err)
+		}
+
+		if v != tc.expected {
+			t.Fatalf("expected %s %s increment to be %s, but got %s", tc.version, tc.rt, tc.expected, v)
+		}
+	}
+
+	c, _ := NewCalVer("YYYY.0M.MICRO")
+	c.Date = date
+	if _, err := c.Increment("2023.01.0", Patch, ""); err == nil {
+		t.Fatal("expected an increment to a date before the version to fail")
+	}
+}
+
+func TestCalVerIncrementWeekYear(t *testing.T) {
+	// 2024-12-30 is in the first ISO week of 2025
+	date := time.Date(2024, time.December, 30, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		format   string
+		version  string
+		expected string
+	}{
+		{"YYYY.0W.MICRO", "2024.52.3", "2025.01.0"},
+		{"YYYY.0W.MICRO", "2025.01.0", "2025.01.1"},
+		{"YY.WW", "24.52", "25.1"},
+		{"YYYY.0M.MICRO", "2024.12.3", "2024.12.4"},
+	}
+
+	for _, tc := range tests {
+		c, err := NewCalVer(tc.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Date = date
+
+		v, err := c.Increment(tc.version, Patch, "")
+		if err != nil {
+			t.Fatalf("error for %s increment: %s", tc.version, err)
+		}
+
+		if v != tc.expected {
+			t.Fatalf("expected %s increment to be %s, but got %s", tc.version, tc.expected, v)
+		}
+	}
+}
+
+func TestSortedBy(t *testing.T) {
+	c, _ := NewCalVer("YYYY.0M.MICRO")
+	vs := SortedBy(c, []string{"2022.10.0", "2022.9.10", "v1.2.3", "2022.09.2", "2021.12.0"})
+
+	expected := []string{"2021.12.0", "2022.09.2", "2022.9.10", "2022.10.0"}
+	if len(vs) != len(expected) {
+		t.Fatalf("expected %d versions, but got %d", len(expected), len(vs))
+	}
+
+	for i, v := range vs {
+		if v.Original() != expected[i] {
+			t.Fatalf("expected versions to sort as %v, but got %s at %d", expected, v.Original(), i)
+		}
+	}
+
+	s, err := NewScheme("CalVer", "YYYY.0M.MICRO")
+	if err != nil || s.Name() != "calver" {
+		t.Fatalf("expected calver scheme, but got %v, %v", s, err)
+	}
+
+	if _, err := NewScheme("semver", ""); err != nil {
+		t.Fatalf("expected semver scheme, but got: %s", err)
+	}
+
+	if _, err := NewScheme("romver", ""); err == nil {
+		t.Fatal("expected an unknown scheme to fail")
+	}
+}
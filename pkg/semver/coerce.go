@@ -0,0 +1,50 @@
+package semver
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ErrNoVersion is returned by Coerce when the text contains nothing version-like
+var ErrNoVersion = errors.New("no version found")
+
+// versionLike matches dot separated numbers, of which only those with up to
+// three components are version-like
+var versionLike = regexp.MustCompile(`(^|[^0-9.])(v?)([0-9]+(?:\.[0-9]+)*)`)
+
+// Coerce extracts the first version-like substring from arbitrary text, such
+// as "release-2021-v3.4" or "foo 1.2 bar", and returns it as a version.
+// Dotted or v prefixed numbers are preferred over a bare number, so
+// "release-2021-v3.4" gives 3.4.0 rather than 2021.0.0. Numbers with more
+// than three dotted components are skipped. Missing components
+// are zero and any prerelease or build metadata is ignored.
+func Coerce(in string) (*Version, error) {
+	var found string
+	for _, m := range versionLike.FindAllStringSubmatch(in, -1) {
+		prefix, num := m[2], m[3]
+
+		// a longer dotted number, such as an IP address, isn't a version
+		if strings.Count(num, ".") > 2 {
+			continue
+		}
+
+		// the v of a word such as "dev3" isn't a prefix
+		if m[1] != "" && unicode.IsLetter(rune(m[1][0])) {
+			prefix = ""
+		}
+		if prefix != "" || strings.Contains(num, ".") {
+			found = prefix + num
+			break
+		}
+		if found == "" {
+			found = num
+		}
+	}
+
+	if found == "" {
+		return nil, ErrNoVersion
+	}
+	return NewVersion(found)
+}
@@ -0,0 +1,41 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+		styled   string
+	}{
+		{"release-2021-v3.4", "3.4.0", "v3.4.0"},
+		{"foo 1.2 bar", "1.2.0", "1.2.0"},
+		{"1.2.3", "1.2.3", "1.2.3"},
+		{"v2", "2.0.0", "v2.0.0"},
+		{"build 42", "42.0.0", "42.0.0"},
+		{"dev3 and 4", "3.0.0", "3.0.0"},
+		{"app-1.2.3-rc.1+linux", "1.2.3", "1.2.3"},
+		{"1.2.3.4 or 2.1", "2.1.0", "2.1.0"},
+		{"version 1.2.", "1.2.0", "1.2.0"},
+		{"go1.18.2", "1.18.2", "1.18.2"},
+	}
+
+	for _, tc := range tests {
+		v, err := Coerce(tc.text)
+		if err != nil {
+			t.Fatalf("error for text %q: %s", tc.text, err)
+		}
+
+		if v.String() != tc.expected || v.Styled() != tc.styled {
+			t.Fatalf("expected text %q to coerce to %s (%s), but got %s (%s)", tc.text, tc.expected, tc.styled, v.String(), v.Styled())
+		}
+	}
+
+	for _, text := range []string{"", "no version here", "v.x", "1.2.3.4", "1.2.3.4.5"} {
+		if _, err := Coerce(text); err != ErrNoVersion {
+			t.Fatalf("expected text %q to have no version, but got: %v", text, err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ParseMode selects the grammar raw version values are checked against
+type ParseMode int
+
+const (
+	// Loose accepts values that can be coerced into a semantic version, such
+	// as "v1.2" or "1.2-beta.5"
+	Loose ParseMode = iota
+	// Strict only accepts values following the SemVer 2.0.0 grammar, see
+	// https://semver.org/spec/v2.0.0.html#backusnaur-form-grammar-for-valid-semver-versions
+	Strict
+)
+
+// parseModeNames are the string representations of the parse modes
+var parseModeNames = [...]string{"loose", "strict"}
+
+// String is the string representation of a ParseMode
+func (m ParseMode) String() string {
+	if m < 0 || int(m) >= len(parseModeNames) {
+		return fmt.Sprintf("ParseMode(%d)", int(m))
+	}
+	return parseModeNames[m]
+}
+
+// Diagnostic describes why a raw version value isn't valid
+type Diagnostic struct {
+	// Index is the position of the value in the list it was provided in
+	Index int
+	// Input is the raw version value
+	Input string
+	// Offset is the position of the offending character in the value, or -1
+	// when the problem isn't with a single character
+	Offset int
+	// Reason describes the problem, e.g. "leading zero in minor component"
+	Reason string
+}
+
+// Error returns the diagnostic as an error message
+func (d *Diagnostic) Error() string {
+	if d.Offset < 0 {
+		return fmt.Sprintf("invalid version %q: %s", d.Input, d.Reason)
+	}
+	return fmt.Sprintf("invalid version %q: %s at offset %d", d.Input, d.Reason, d.Offset)
+}
+
+// components names the numeric components of a version
+var components = [...]string{"major", "minor", "patch"}
+
+// Diagnose returns a diagnostic describing why a raw version value isn't
+// valid in the parse mode, or nil if it's valid
+func Diagnose(in string, mode ParseMode) *Diagnostic {
+	_, perr := semver.NewVersion(in)
+	d := diagnose(in, mode)
+
+	// in loose mode the parser is the authority on what can be coerced, the
+	// grammar check only explains its verdict
+	if mode == Loose {
+		if perr == nil {
+			return nil
+		}
+		if d == nil {
+			d = &Diagnostic{Input: in, Offset: -1, Reason: strings.ToLower(perr.Error())}
+		}
+		return d
+	}
+
+	if d == nil && perr != nil {
+		d = &Diagnostic{Input: in, Offset: -1, Reason: strings.ToLower(perr.Error())}
+	}
+	return d
+}
+
+// diagnose checks a raw version value against the grammar of the parse mode
+func diagnose(in string, mode ParseMode) *Diagnostic {
+	fail := func(offset int, format string, a ...interface{}) *Diagnostic {
+		return &Diagnostic{Input: in, Offset: offset, Reason: fmt.Sprintf(format, a...)}
+	}
+
+	if in == "" {
+		return fail(-1, "empty version")
+	}
+
+	start := 0
+	if strings.HasPrefix(in, "v") {
+		if mode == Strict {
+			return fail(0, "v prefix is not allowed")
+		}
+		start = 1
+	}
+
+	end, metaIdx := len(in), strings.IndexByte(in, '+')
+	if metaIdx >= 0 {
+		end = metaIdx
+	}
+	coreEnd, preIdx := end, strings.IndexByte(in[:end], '-')
+	if preIdx >= 0 {
+		coreEnd = preIdx
+	}
+
+	parts := strings.Split(in[start:coreEnd], ".")
+	if len(parts) > 3 {
+		return fail(start+len(strings.Join(parts[:3], ".")), "too many components")
+	}
+
+	offset := start
+	for i, p := range parts {
+		if p == "" {
+			return fail(offset, "empty %s component", components[i])
+		}
+		for j, r := range p {
+			if r < '0' || r > '9' {
+				return fail(offset+j, "invalid character %q in %s component", r, components[i])
+			}
+		}
+		if mode == Strict && len(p) > 1 && p[0] == '0' {
+			return fail(offset, "leading zero in %s component", components[i])
+		}
+		if _, err := strconv.ParseUint(p, 10, 64); err != nil {
+			return fail(offset, "%s component out of range", components[i])
+		}
+		offset += len(p) + 1
+	}
+	if mode == Strict && len(parts) < 3 {
+		return fail(-1, "missing %s component", components[len(parts)])
+	}
+
+	if preIdx >= 0 {
+		if d := identifiers(in, in[preIdx+1:end], preIdx+1, "prerelease", true); d != nil {
+			return d
+		}
+	}
+
+	if metaIdx >= 0 {
+		if d := identifiers(in, in[metaIdx+1:], metaIdx+1, "build metadata", false); d != nil {
+			return d
+		}
+	}
+
+	return nil
+}
+
+// identifiers checks the dot separated prerelease or build metadata
+// identifiers starting at an offset of a raw version value
+func identifiers(in string, s string, offset int, name string, numeric bool) *Diagnostic {
+	fail := func(offset int, format string, a ...interface{}) *Diagnostic {
+		return &Diagnostic{Input: in, Offset: offset, Reason: fmt.Sprintf(format, a...)}
+	}
+
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return fail(offset, "empty %s identifier", name)
+		}
+
+		digits := true
+		for j, r := range id {
+			switch {
+			case r >= '0' && r <= '9':
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '-':
+				digits = false
+			default:
+				return fail(offset+j, "invalid character %q in %s", r, name)
+			}
+		}
+
+		if numeric && digits && len(id) > 1 && id[0] == '0' {
+			return fail(offset, "leading zero in %s identifier", name)
+		}
+		offset += len(id) + 1
+	}
+	return nil
+}
+
+// ListWithDiagnostics takes a collection of raw version values and returns a
+// list of the versions that are valid in the parse mode along with a
+// diagnostic for each value that isn't
+func ListWithDiagnostics(in []string, mode ParseMode) ([]string, []*Diagnostic) {
+	var r []string
+	var ds []*Diagnostic
+	for i, raw := range in {
+		if d := Diagnose(raw, mode); d != nil {
+			d.Index = i
+			ds = append(ds, d)
+			continue
+		}
+
+		v, err := NewVersion(raw)
+		if err != nil {
+			ds = append(ds, &Diagnostic{Index: i, Input: raw, Offset: -1, Reason: strings.ToLower(err.Error())})
+			continue
+		}
+		r = append(r, v.String())
+	}
+	return r, ds
+}
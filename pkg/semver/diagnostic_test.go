@@ -0,0 +1,95 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestParseModes(t *testing.T) {
+	tests := []struct {
+		mode     ParseMode
+		expected string
+	}{
+		{Loose, "loose"},
+		{Strict, "strict"},
+		{ParseMode(42), "ParseMode(42)"},
+		{ParseMode(-1), "ParseMode(-1)"},
+	}
+
+	for _, tc := range tests {
+		if s := tc.mode.String(); s != tc.expected {
+			t.Fatalf("expected parse mode of '%s', but instead got '%s'", tc.expected, s)
+		}
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		version string
+		mode    ParseMode
+		valid   bool
+		offset  int
+		reason  string
+	}{
+		{"1.2.3", Loose, true, 0, ""},
+		{"1.2.3", Strict, true, 0, ""},
+		{"1.2.3-alpha.1+build.01", Strict, true, 0, ""},
+		{"v1.2", Loose, true, 0, ""},
+		{"01.2.3", Loose, true, 0, ""},
+		{"v1.2", Strict, false, 0, "v prefix is not allowed"},
+		{"1.2", Strict, false, -1, "missing patch component"},
+		{"1", Strict, false, -1, "missing minor component"},
+		{"1.02.3", Strict, false, 2, "leading zero in minor component"},
+		{"1.2.3-alpha.01", Loose, false, 12, "leading zero in prerelease identifier"},
+		{"1.2.3.4", Loose, false, 5, "too many components"},
+		{"v1.2.3.4", Loose, false, 6, "too many components"},
+		{"1.2.beta", Loose, false, 4, "invalid character 'b' in patch component"},
+		{"\n1.2", Loose, false, 0, "invalid character '\\n' in major component"},
+		{"1..3", Loose, false, 2, "empty minor component"},
+		{"1.2.3-a_b", Loose, false, 7, "invalid character '_' in prerelease"},
+		{"1.2.3-a..b", Strict, false, 8, "empty prerelease identifier"},
+		{"1.2.3+", Strict, false, 6, "empty build metadata identifier"},
+		{"1.2.3+sha.ab$", Strict, false, 12, "invalid character '$' in build metadata"},
+		{"99999999999999999999.0.0", Loose, false, 0, "major component out of range"},
+		{"", Loose, false, -1, "empty version"},
+	}
+
+	for _, tc := range tests {
+		d := Diagnose(tc.version, tc.mode)
+		if tc.valid {
+			if d != nil {
+				t.Fatalf("expected version %q to be valid in %s mode, but got: %s", tc.version, tc.mode, d)
+			}
+			continue
+		}
+
+		if d == nil {
+			t.Fatalf("expected version %q to be invalid in %s mode", tc.version, tc.mode)
+		}
+
+		if d.Reason != tc.reason || d.Offset != tc.offset {
+			t.Fatalf("expected version %q to be invalid with %q at offset %d, but got %q at offset %d", tc.version, tc.reason, tc.offset, d.Reason, d.Offset)
+		}
+	}
+}
+
+func TestListWithDiagnostics(t *testing.T) {
+	raw := []string{"1.2.3", "1.2.beta", "v1.3", "1.2.3-alpha.01"}
+
+	versions, ds := ListWithDiagnostics(raw, Loose)
+	if !Equal(versions, []string{"1.2.3", "1.3.0"}) {
+		t.Fatalf("expected versions [1.2.3 1.3.0], but got %v", versions)
+	}
+
+	if len(ds) != 2 || ds[0].Index != 1 || ds[1].Index != 3 {
+		t.Fatalf("expected diagnostics for inputs 1 and 3, but got %v", ds)
+	}
+
+	versions, ds = ListWithDiagnostics(raw, Strict)
+	if !Equal(versions, []string{"1.2.3"}) {
+		t.Fatalf("expected versions [1.2.3], but got %v", versions)
+	}
+
+	if len(ds) != 3 || ds[1].Index != 2 || ds[1].Error() != `invalid version "v1.3": v prefix is not allowed at offset 0` {
+		t.Fatalf("expected strict diagnostics for inputs 1, 2 and 3, but got %v", ds)
+	}
+}
@@ -0,0 +1,72 @@
+package semver
+
+// Difference describes how two versions differ
+type Difference struct {
+	// Order is -1, 0 or 1 depending on whether the first version is lower
+	// than, equal to or greater than the second version
+	Order int
+	// Type is the most significant component that differs. It is only
+	// meaningful when Changed is true
+	Type ReleaseType
+	// Changed is false when the versions are identical
+	Changed bool
+}
+
+// String returns the release type of the difference or an empty string if
+// the versions are identical
+func (d Difference) String() string {
+	if !d.Changed {
+		return ""
+	}
+	return d.Type.String()
+}
+
+// Diff compares two versions and returns their ordering along with the most
+// significant component that differs. This function largely mimics the diff
+// logic found in https://github.com/npm/node-semver, with the addition of
+// reporting versions that only differ by build metadata.
+//
+// 1.2.3 and 2.0.0 differ by major
+// 1.2.3 and 1.2.4-beta.0 differ by prepatch
+// 1.2.3-beta.0 and 1.2.3 differ by prerelease
+// 1.2.3+build.1 and 1.2.3+build.2 differ by build
+func Diff(a, b string) (Difference, error) {
+	var d Difference
+	v1, err := NewVersion(a)
+	if err != nil {
+		return d, err
+	}
+
+	v2, err := NewVersion(b)
+	if err != nil {
+		return d, err
+	}
+
+	d.Order = v1.Compare(v2)
+	if d.Order == 0 {
+		if v1.Metadata() != v2.Metadata() {
+			d.Type = Build
+			d.Changed = true
+		}
+		return d, nil
+	}
+
+	d.Changed = true
+	hasPre := len(v1.Prerelease()) > 0 || len(v2.Prerelease()) > 0
+	switch {
+	case v1.Major() != v2.Major():
+		d.Type = Major
+	case v1.Minor() != v2.Minor():
+		d.Type = Minor
+	case v1.Patch() != v2.Patch():
+		d.Type = Patch
+	default:
+		d.Type = PreRelease
+		return d, nil
+	}
+
+	if hasPre {
+		d.Type += PreMajor - Major
+	}
+	return d, nil
+}
@@ -0,0 +1,47 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		v1       string
+		v2       string
+		order    int
+		expected string
+		err      bool
+	}{
+		{"1.2.3", "2.0.0", -1, "major", false},
+		{"2.0.0", "1.2.3", 1, "major", false},
+		{"1.2.3", "1.3.0", -1, "minor", false},
+		{"1.2.3", "1.2.4", -1, "patch", false},
+		{"1.2.3", "2.0.0-rc.0", -1, "premajor", false},
+		{"1.2.3-rc.0", "1.3.0", -1, "preminor", false},
+		{"1.2.3", "1.2.4-beta.0", -1, "prepatch", false},
+		{"1.2.3-beta.0", "1.2.3", -1, "prerelease", false},
+		{"1.2.3-beta.0", "1.2.3-beta.1", -1, "prerelease", false},
+		{"1.2.3+build.1", "1.2.3+build.2", 0, "build", false},
+		{"v1.2.3", "1.2.3", 0, "", false},
+		{"1.2", "1.2.0", 0, "", false},
+		{"1.2.beta", "1.2.3", 0, "", true},
+		{"1.2.3", "foo", 0, "", true},
+	}
+
+	for _, tc := range tests {
+		d, err := Diff(tc.v1, tc.v2)
+		if tc.err && err == nil {
+			t.Fatalf("expected error for versions %s and %s", tc.v1, tc.v2)
+		} else if !tc.err && err != nil {
+			t.Fatalf("error for versions %s and %s: %s", tc.v1, tc.v2, err)
+		}
+
+		if d.Order != tc.order {
+			t.Fatalf("expected versions %s and %s to have order %d, but got %d", tc.v1, tc.v2, tc.order, d.Order)
+		}
+
+		if d.String() != tc.expected {
+			t.Fatalf("expected versions %s and %s to differ by %q, but got %q", tc.v1, tc.v2, tc.expected, d.String())
+		}
+	}
+}
@@ -0,0 +1,373 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PackageVersion is the version of a Debian or RPM package, made of an
+// optional epoch, the upstream version and an optional revision, e.g.
+// 1:2.3.4-1ubuntu2 or 2.3.4-1.el8
+type PackageVersion struct {
+	// Epoch is the epoch given before a colon, 0 when there is none
+	Epoch int
+	// Upstream is the upstream version, the version of RPM packages
+	Upstream string
+	// Revision is the package revision after the last hyphen, the release
+	// of RPM packages, or an empty string when there is none
+	Revision string
+
+	raw    string
+	scheme *distroScheme
+}
+
+// distroScheme is the versioning scheme of a distribution package format
+type distroScheme struct {
+	name string
+	// valid reports whether a character is allowed in the upstream version
+	// and revision
+	valid func(c rune) bool
+	// hyphens allows hyphens in the upstream version of versions with a
+	// revision
+	hyphens bool
+	// compare compares the upstream versions or revisions of two versions
+	compare func(a, b string) int
+}
+
+// Debian is the versioning scheme of Debian packages, ordered the way dpkg
+// orders them, see
+// https://www.debian.org/doc/debian-policy/ch-controlfields.html#version
+var Debian Scheme = debianScheme
+
+// RPM is the versioning scheme of RPM packages, ordered the way rpmvercmp
+// orders them, see https://rpm-software-management.github.io/rpm/manual/dependencies.html#versioning
+var RPM Scheme = rpmScheme
+
+var debianScheme = &distroScheme{
+	name: "deb",
+	valid: func(c rune) bool {
+		return isAlnum(c) || strings.ContainsRune(".+~", c)
+	},
+	hyphens: true,
+	compare: CompareDebian,
+}
+
+var rpmScheme = &distroScheme{
+	name: "rpm",
+	valid: func(c rune) bool {
+		return isAlnum(c) || strings.ContainsRune(".+~^_", c)
+	},
+	compare: CompareRPM,
+}
+
+// ParseDebian parses the version of a Debian package
+func ParseDebian(in string) (*PackageVersion, error) {
+	return debianScheme.parse(in)
+}
+
+// ParseRPM parses the version of an RPM package
+func ParseRPM(in string) (*PackageVersion, error) {
+	return rpmScheme.parse(in)
+}
+
+// parse splits a raw version value into its epoch, upstream version and
+// revision. The upstream version must start with a digit.
+func (s *distroScheme) parse(in string) (*PackageVersion, error) {
+	fail := func(reason string) (*PackageVersion, error) {
+		return nil, fmt.Errorf("invalid version %q: %s", in, reason)
+	}
+
+	v := &PackageVersion{raw: in, scheme: s}
+	rest := in
+	if i := strings.IndexByte(rest, ':'); i >= 0 {
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil || n < 0 {
+			return fail("epoch must be a number")
+		}
+		v.Epoch, rest = n, rest[i+1:]
+	}
+
+	if i := strings.LastIndexByte(rest, '-'); i >= 0 {
+		v.Revision, rest = rest[i+1:], rest[:i]
+		if v.Revision == "" {
+			return fail("empty revision")
+		}
+	}
+	v.Upstream = rest
+
+	if v.Upstream == "" {
+		return fail("empty upstream version")
+	}
+	if c := v.Upstream[0]; c < '0' || c > '9' {
+		return fail("upstream version must start with a digit")
+	}
+
+	for _, c := range v.Upstream {
+		if !s.valid(c) && !(c == '-' && s.hyphens) {
+			return fail(fmt.Sprintf("invalid character %q in upstream version", c))
+		}
+	}
+	for _, c := range v.Revision {
+		if !s.valid(c) {
+			return fail(fmt.Sprintf("invalid character %q in revision", c))
+		}
+	}
+	return v, nil
+}
+
+// Name returns the name the scheme is selected by
+func (s *distroScheme) Name() string {
+	return s.name
+}
+
+// Parse parses a raw version value of the package format
+func (s *distroScheme) Parse(in string) (Ordered, error) {
+	return s.parse(in)
+}
+
+// Increment returns an error, package revisions are maintained by hand
+func (s *distroScheme) Increment(in string, rt ReleaseType, ident string) (string, error) {
+	return "", fmt.Errorf("%s versions can't be incremented: %w", s.name, ErrUnsupportedReleaseType)
+}
+
+// String returns the version, leaving out an epoch of 0
+func (v *PackageVersion) String() string {
+	s := v.Upstream
+	if v.Epoch != 0 {
+		s = strconv.Itoa(v.Epoch) + ":" + s
+	}
+	if v.Revision != "" {
+		s += "-" + v.Revision
+	}
+	return s
+}
+
+// Original returns the raw value the version was parsed from
+func (v *PackageVersion) Original() string {
+	if v.raw == "" {
+		return v.String()
+	}
+	return v.raw
+}
+
+// Compare compares the epochs, upstream versions and revisions of the
+// versions in turn, following the package format of the version
+func (v *PackageVersion) Compare(o Ordered) int {
+	ov, ok := o.(*PackageVersion)
+	if !ok {
+		return strings.Compare(v.String(), o.String())
+	}
+
+	cmp := CompareDebian
+	if v.scheme != nil {
+		cmp = v.scheme.compare
+	}
+
+	if c := compareInts(v.Epoch, ov.Epoch); c != 0 {
+		return c
+	}
+	if c := cmp(v.Upstream, ov.Upstream); c != 0 {
+		return c
+	}
+	return cmp(v.Revision, ov.Revision)
+}
+
+// CompareDebian compares two upstream versions or revisions the way dpkg
+// does. Non-digit parts compare with letters sorting before other characters
+// and ~ sorting before anything, even the end of the value, while digit parts
+// compare numerically.
+func CompareDebian(a, b string) int {
+	// order returns the sort weight of a non-digit character, 0 at the end
+	order := func(s string, i int) int {
+		switch {
+		case i >= len(s) || isDigit(s[i]):
+			return 0
+		case isLetter(s[i]):
+			return int(s[i])
+		case s[i] == '~':
+			return -1
+		}
+		return int(s[i]) + 256
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			if c := compareInts(order(a, i), order(b, j)); c != 0 {
+				return c
+			}
+			i, j = i+1, j+1
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+
+		diff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if diff == 0 {
+				diff = compareInts(int(a[i]), int(b[j]))
+			}
+			i, j = i+1, j+1
+		}
+
+		switch {
+		case i < len(a) && isDigit(a[i]):
+			return 1
+		case j < len(b) && isDigit(b[j]):
+			return -1
+		case diff != 0:
+			return diff
+		}
+	}
+	return 0
+}
+
+// CompareRPM compares two versions or releases the way rpmvercmp does. The
+// values are split into runs of digits and of letters, separated by any other
+// character. Digit runs compare numerically and are newer than letter runs,
+// ~ sorts before anything and ^ sorts after the end of the value but before
+// any other run.
+func CompareRPM(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	separator := func(c byte) bool {
+		return !isDigit(c) && !isLetter(c) && c != '~' && c != '^'
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && separator(a[i]) {
+			i++
+		}
+		for j < len(b) && separator(b[j]) {
+			j++
+		}
+
+		// tilde sorts before everything else
+		if (i < len(a) && a[i] == '~') || (j < len(b) && b[j] == '~') {
+			if i >= len(a) || a[i] != '~' {
+				return 1
+			}
+			if j >= len(b) || b[j] != '~' {
+				return -1
+			}
+			i, j = i+1, j+1
+			continue
+		}
+
+		// caret sorts after the end, but before anything else
+		if (i < len(a) && a[i] == '^') || (j < len(b) && b[j] == '^') {
+			switch {
+			case i >= len(a):
+				return -1
+			case j >= len(b):
+				return 1
+			case a[i] != '^':
+				return 1
+			case b[j] != '^':
+				return -1
+			}
+			i, j = i+1, j+1
+			continue
+		}
+
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		run := isLetter
+		numeric := isDigit(a[i])
+		if numeric {
+			run = isDigit
+		}
+
+		si, sj := i, j
+		for i < len(a) && run(a[i]) {
+			i++
+		}
+		for j < len(b) && run(b[j]) {
+			j++
+		}
+
+		// runs of different kinds, digits are newer
+		if j == sj {
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+
+		ra, rb := a[si:i], b[sj:j]
+		if numeric {
+			ra, rb = strings.TrimLeft(ra, "0"), strings.TrimLeft(rb, "0")
+			if c := compareInts(len(ra), len(rb)); c != 0 {
+				return c
+			}
+		}
+		if c := strings.Compare(ra, rb); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case i >= len(a) && j >= len(b):
+		return 0
+	case i < len(a):
+		return 1
+	}
+	return -1
+}
+
+// SemVerToDebian converts a semantic version to the upstream version of a
+// Debian package. The prerelease follows a ~ so it sorts before the release,
+// e.g. 1.2.0-rc.1 becomes 1.2.0~rc.1, and the build metadata follows a +.
+// Hyphens within identifiers become dots.
+func SemVerToDebian(in string) (string, error) {
+	return semverToDistro(in, "~", "+")
+}
+
+// SemVerToRPM converts a semantic version to the version of an RPM package.
+// The prerelease follows a ~ so it sorts before the release, e.g. 1.2.0-rc.1
+// becomes 1.2.0~rc.1, and the build metadata follows a ^ so it sorts after
+// the release. Hyphens within identifiers become dots.
+func SemVerToRPM(in string) (string, error) {
+	return semverToDistro(in, "~", "^")
+}
+
+// semverToDistro converts a semantic version using separators for the
+// prerelease and build metadata
+func semverToDistro(in string, pre string, meta string) (string, error) {
+	v, err := NewVersion(in)
+	if err != nil {
+		return "", err
+	}
+
+	dots := strings.NewReplacer("-", ".")
+	s := fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+	if p := v.Prerelease(); len(p) > 0 {
+		s += pre + dots.Replace(strings.Join(p, "."))
+	}
+	if m := v.Metadata(); m != "" {
+		s += meta + dots.Replace(m)
+	}
+	return s, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isAlnum(c rune) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
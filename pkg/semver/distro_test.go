@@ -0,0 +1,191 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePackageVersion(t *testing.T) {
+	tests := []struct {
+		scheme   Scheme
+		version  string
+		epoch    int
+		upstream string
+		revision string
+		valid    bool
+	}{
+		{Debian, "1:2.3.4-1ubuntu2", 1, "2.3.4", "1ubuntu2", true},
+		{Debian, "2.3.4", 0, "2.3.4", "", true},
+		{Debian, "2.3.4-rc1-1", 0, "2.3.4-rc1", "1", true},
+		{Debian, "1.2.0~rc.1+dfsg", 0, "1.2.0~rc.1+dfsg", "", true},
+		{Debian, "v2.3.4", 0, "", "", false},
+		{Debian, "a:2.3.4", 0, "", "", false},
+		{Debian, "2.3.4-", 0, "", "", false},
+		{Debian, "2.3_4", 0, "", "", false},
+		{RPM, "2.3.4-1.el8", 0, "2.3.4", "1.el8", true},
+		{RPM, "3:2.3.4^git1_2-1", 3, "2.3.4^git1_2", "1", true},
+		{RPM, "2.3.4-1-1", 0, "", "", false},
+		{RPM, ":2.3.4", 0, "", "", false},
+	}
+
+	for _, tc := range tests {
+		o, err := tc.scheme.Parse(tc.version)
+		if !tc.valid {
+			if err == nil {
+				t.Fatalf("expected %s version %s to be invalid", tc.scheme.Name(), tc.version)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("error for %s version %s: %s", tc.scheme.Name(), tc.version, err)
+		}
+
+		v := o.(*PackageVersion)
+		if v.Epoch != tc.epoch || v.Upstream != tc.upstream || v.Revision != tc.revision {
+			t.Fatalf("expected %s version %s to be %d, %s and %s, but got %d, %s and %s", tc.scheme.Name(), tc.version, tc.epoch, tc.upstream, tc.revision, v.Epoch, v.Upstream, v.Revision)
+		}
+	}
+}
+
+func TestCompareDebian(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "1.00", 0},
+		{"1.0", "1.0-0", 0},
+		{"1.0", "1.0-1", -1},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~~", "1.0~~a", -1},
+		{"1.0~~a", "1.0~", -1},
+		{"1.0~", "1.0", -1},
+		{"1.0", "1.0a", -1},
+		{"1.0a", "1.0+", -1},
+		{"1.0.9", "1.0.10", -1},
+		{"1:0.1", "2.0", 1},
+		{"1.0-1ubuntu2", "1.0-1ubuntu1", 1},
+		{"1.0-1", "1.0-1ubuntu1", -1},
+		{"1.2.0~rc.1", "1.2.0~rc.2", -1},
+	}
+
+	for _, tc := range tests {
+		a, err := ParseDebian(tc.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ParseDebian(tc.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c := a.Compare(b); c != tc.expected {
+			t.Fatalf("expected comparing %s to %s to be %d, but got %d", tc.a, tc.b, tc.expected, c)
+		}
+		if c := b.Compare(a); c != -tc.expected {
+			t.Fatalf("expected comparing %s to %s to be %d, but got %d", tc.b, tc.a, -tc.expected, c)
+		}
+	}
+}
+
+func TestCompareRPM(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "2.0", -1},
+		{"2.0.1", "2.0", 1},
+		{"2.0.1a", "2.0.1", 1},
+		{"5.5p1", "5.5p2", -1},
+		{"5.5p10", "5.5p2", 1},
+		{"10xyz", "10.1xyz", -1},
+		{"1.1", "1.a", 1},
+		{"1.0010", "1.9", 1},
+		{"1.05", "1.5", 0},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0~rc1", "1.0~rc1~git1", 1},
+		{"1.0^", "1.0", 1},
+		{"1.0^git1", "1.0", 1},
+		{"1.0^git1", "1.0.1", -1},
+		{"1.0^git1", "1.0~rc1", 1},
+		{"2.3.4-1.el8", "2.3.4-1.el9", -1},
+		{"2.3.4-2.el8", "2.3.4-10.el8", -1},
+		{"1:1.0", "2.0", 1},
+	}
+
+	for _, tc := range tests {
+		a, err := ParseRPM(tc.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ParseRPM(tc.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c := a.Compare(b); c != tc.expected {
+			t.Fatalf("expected comparing %s to %s to be %d, but got %d", tc.a, tc.b, tc.expected, c)
+		}
+		if c := b.Compare(a); c != -tc.expected {
+			t.Fatalf("expected comparing %s to %s to be %d, but got %d", tc.b, tc.a, -tc.expected, c)
+		}
+	}
+}
+
+func TestSortedListBy(t *testing.T) {
+	in := []string{"1:1.0-1", "1.2.0-1", "1.2.0~rc.1-1", "v1.0", "0:1.1-1"}
+
+	expected := []string{"1.1-1", "1.2.0~rc.1-1", "1.2.0-1", "1:1.0-1"}
+	vs := SortedListBy(Debian, in)
+	if len(vs) != len(expected) {
+		t.Fatalf("expected %v, but got %v", expected, vs)
+	}
+	for i, v := range vs {
+		if v != expected[i] {
+			t.Fatalf("expected %v, but got %v", expected, vs)
+		}
+	}
+
+	if _, err := RPM.Increment("1.0-1", Patch, ""); !errors.Is(err, ErrUnsupportedReleaseType) {
+		t.Fatalf("expected rpm increments to be unsupported, but got: %v", err)
+	}
+}
+
+func TestSemVerToDistro(t *testing.T) {
+	tests := []struct {
+		version string
+		deb     string
+		rpm     string
+	}{
+		{"1.2.0", "1.2.0", "1.2.0"},
+		{"v1.2", "1.2.0", "1.2.0"},
+		{"1.2.0-rc.1", "1.2.0~rc.1", "1.2.0~rc.1"},
+		{"1.2.0-pre-release.1", "1.2.0~pre.release.1", "1.2.0~pre.release.1"},
+		{"1.2.0-rc.1+build.5", "1.2.0~rc.1+build.5", "1.2.0~rc.1^build.5"},
+	}
+
+	for _, tc := range tests {
+		deb, err := SemVerToDebian(tc.version)
+		if err != nil || deb != tc.deb {
+			t.Fatalf("expected %s to convert to %s, but got %s, %v", tc.version, tc.deb, deb, err)
+		}
+
+		rpm, err := SemVerToRPM(tc.version)
+		if err != nil || rpm != tc.rpm {
+			t.Fatalf("expected %s to convert to %s, but got %s, %v", tc.version, tc.rpm, rpm, err)
+		}
+	}
+
+	// prereleases sort before the release once converted
+	pre, _ := SemVerToDebian("1.2.0-rc.1")
+	if CompareDebian(pre, "1.2.0") >= 0 {
+		t.Fatalf("expected %s to sort before 1.2.0", pre)
+	}
+
+	if _, err := SemVerToRPM("1.x"); err == nil {
+		t.Fatal("expected an invalid version to fail")
+	}
+}
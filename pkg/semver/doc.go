@@ -0,0 +1,14 @@
+// Package semver provides parsing, validation, sorting and incrementing of
+// semantic versions. It is the library behind the semver command line tool
+// and can be imported directly by Go programs.
+//
+// # Compatibility
+//
+// The exported API of this package follows the semantic versioning rules of
+// the go-semver module itself. Within a major version, exported identifiers
+// are not removed or renamed, function signatures do not change and the
+// results of Valid, Increment, List and SortedList for a given input do not
+// change, other than to fix behavior that contradicts the documentation.
+// New functions, types and ReleaseType constants may be added in minor
+// releases, so callers should not assume the set of release types is closed.
+package semver
@@ -0,0 +1,82 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ImageTag is a tag a container image built for a version is pushed as
+type ImageTag struct {
+	// Name is the tag, e.g. 1.4.2, 1.4, 1 or latest
+	Name string
+	// Floating reports whether the tag moves between releases
+	Floating bool
+	// Skipped explains why the tag shouldn't be pushed, or is empty when it
+	// should
+	Skipped string
+}
+
+// ImageTags returns the tags a container image built for a version is pushed
+// as, given the versions released before: the version itself, and the
+// floating major.minor, major and latest tags. A floating tag is skipped when
+// a higher release exists in its line, such as when the version is a backport,
+// and all floating tags are skipped for prereleases. Major version zero has no
+// major tag. Existing prereleases and values that aren't valid versions are
+// ignored. The build metadata of the version is left out of its tag.
+func ImageTags(in string, existing []string) ([]ImageTag, error) {
+	v, err := NewVersion(in)
+	if err != nil {
+		return nil, err
+	}
+
+	full := strings.TrimSuffix(v.String(), "+"+v.Metadata())
+
+	minor := strconv.FormatUint(v.Major(), 10) + "." + strconv.FormatUint(v.Minor(), 10)
+	major := strconv.FormatUint(v.Major(), 10)
+	tags := []ImageTag{
+		{Name: full},
+		{Name: minor, Floating: true},
+		{Name: major, Floating: true},
+		{Name: "latest", Floating: true},
+	}
+
+	if len(v.Prerelease()) > 0 {
+		for i := range tags[1:] {
+			tags[i+1].Skipped = fmt.Sprintf("%s is a prerelease", full)
+		}
+		return tags, nil
+	}
+
+	if v.Major() == 0 {
+		tags[2].Skipped = "major version zero has no major tag"
+	}
+
+	// the highest releases that are higher than the version, in its minor
+	// and major lines and overall
+	var higher [3]*Version
+	for _, raw := range existing {
+		e, err := NewVersion(raw)
+		if err != nil || len(e.Prerelease()) > 0 || !e.GreaterThan(v) {
+			continue
+		}
+
+		lines := [3]bool{
+			e.Major() == v.Major() && e.Minor() == v.Minor(),
+			e.Major() == v.Major(),
+			true,
+		}
+		for i, in := range lines {
+			if in && (higher[i] == nil || e.GreaterThan(higher[i])) {
+				higher[i] = e
+			}
+		}
+	}
+
+	for i, h := range higher {
+		if h != nil && tags[i+1].Skipped == "" {
+			tags[i+1].Skipped = fmt.Sprintf("%s is higher than %s", h, full)
+		}
+	}
+	return tags, nil
+}
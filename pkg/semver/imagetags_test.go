@@ -0,0 +1,56 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImageTags(t *testing.T) {
+	existing := []string{"1.3.0", "1.4.0", "1.4.1", "1.5.0-rc.1", "2.0.0-beta.1", "0.9.3", "foo"}
+
+	tests := []struct {
+		version  string
+		existing []string
+		pushed   string
+		skipped  string
+	}{
+		{"1.4.2", existing, "1.4.2 1.4 1 latest", ""},
+		{"v1.4.2+build.5", existing, "1.4.2 1.4 1 latest", ""},
+		{"1.3.1", existing, "1.3.1 1.3", "1 latest"},
+		{"1.4.1", existing, "1.4.1 1.4 1 latest", ""},
+		{"1.4.0", existing, "1.4.0", "1.4 1 latest"},
+		{"0.9.4", existing, "0.9.4 0.9", "0 latest"},
+		{"0.10.0", []string{"0.9.3"}, "0.10.0 0.10 latest", "0"},
+		{"1.5.0-rc.2", existing, "1.5.0-rc.2", "1.5 1 latest"},
+		{"1.0.0", nil, "1.0.0 1.0 1 latest", ""},
+	}
+
+	for _, tc := range tests {
+		tags, err := ImageTags(tc.version, tc.existing)
+		if err != nil {
+			t.Fatalf("error for version %s: %s", tc.version, err)
+		}
+
+		var pushed, skipped []string
+		for _, tag := range tags {
+			if tag.Skipped == "" {
+				pushed = append(pushed, tag.Name)
+			} else {
+				skipped = append(skipped, tag.Name)
+			}
+		}
+
+		if strings.Join(pushed, " ") != tc.pushed || strings.Join(skipped, " ") != tc.skipped {
+			t.Fatalf("expected version %s to push %q and skip %q, but got %q and %q", tc.version, tc.pushed, tc.skipped, pushed, skipped)
+		}
+	}
+
+	tags, _ := ImageTags("1.3.1", existing)
+	if tags[2].Skipped != "1.4.1 is higher than 1.3.1" {
+		t.Fatalf("expected major tag to be skipped for 1.4.1, but got %q", tags[2].Skipped)
+	}
+
+	if _, err := ImageTags("1.x", existing); err == nil {
+		t.Fatal("expected an invalid version to fail")
+	}
+}
@@ -0,0 +1,442 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// mavenQualifiers are the well known qualifiers of Maven versions, in order.
+// The empty qualifier is the release.
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+// mavenAliases maps the alternative spellings of qualifiers
+var mavenAliases = map[string]string{
+	"ga":      "",
+	"final":   "",
+	"release": "",
+	"cr":      "rc",
+}
+
+// mavenShorthands maps the qualifiers that may be abbreviated to a letter
+// when followed by a number, such as M1
+var mavenShorthands = map[string]string{
+	"a": "alpha",
+	"b": "beta",
+	"m": "milestone",
+}
+
+// mavenPattern are the characters allowed in a Maven version, which must
+// start with a digit and have no empty components
+var mavenPattern = regexp.MustCompile(`^[0-9][a-zA-Z0-9]*(?:[.-][a-zA-Z0-9]+)*$`)
+
+// mavenRelease splits a Maven version into its numeric components and its
+// qualifier
+var mavenRelease = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)*)(?:([.-]?)([a-zA-Z][a-zA-Z0-9.-]*))?$`)
+
+// mavenNumbered splits a qualifier into its label and trailing number
+var mavenNumbered = regexp.MustCompile(`^(.*?)([.-]?)([0-9]+)$`)
+
+// mavenItem is an item of a parsed Maven version: a number, a qualifier or a
+// list of items following a hyphen or a change from letters to digits
+type mavenItem interface {
+	// compare compares the item with another item, or with the absence of
+	// an item when nil
+	compare(o mavenItem) int
+	// isNull reports whether the item is equivalent to its absence
+	isNull() bool
+}
+
+// mavenInt is a number item, without leading zeros
+type mavenInt string
+
+// mavenString is a qualifier item, lowercased and without aliases
+type mavenString string
+
+// mavenList is a list of items
+type mavenList []mavenItem
+
+func (i mavenInt) isNull() bool {
+	return i == ""
+}
+
+func (i mavenInt) compare(o mavenItem) int {
+	switch o := o.(type) {
+	case nil:
+		if i.isNull() {
+			return 0
+		}
+		return 1
+	case mavenInt:
+		if c := compareInts(len(i), len(o)); c != 0 {
+			return c
+		}
+		return strings.Compare(string(i), string(o))
+	}
+	return 1
+}
+
+// comparable returns the string a qualifier orders by, unknown qualifiers
+// ordering after the well known ones
+func (s mavenString) comparable() string {
+	for i, q := range mavenQualifiers {
+		if string(s) == q {
+			return strconv.Itoa(i)
+		}
+	}
+	return fmt.Sprintf("%d-%s", len(mavenQualifiers), s)
+}
+
+func (s mavenString) isNull() bool {
+	return s == ""
+}
+
+func (s mavenString) compare(o mavenItem) int {
+	switch o := o.(type) {
+	case nil:
+		return strings.Compare(s.comparable(), mavenString("").comparable())
+	case mavenString:
+		return strings.Compare(s.comparable(), o.comparable())
+	}
+	return -1
+}
+
+func (l *mavenList) isNull() bool {
+	return len(*l) == 0
+}
+
+func (l *mavenList) compare(o mavenItem) int {
+	switch o := o.(type) {
+	case nil:
+		for _, i := range *l {
+			if c := i.compare(nil); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case mavenInt:
+		return -1
+	case mavenString:
+		return 1
+	case *mavenList:
+		for k := 0; k < len(*l) || k < len(*o); k++ {
+			var a, b mavenItem
+			if k < len(*l) {
+				a = (*l)[k]
+			}
+			if k < len(*o) {
+				b = (*o)[k]
+			}
+
+			var c int
+			switch {
+			case a == nil && b == nil:
+			case a == nil:
+				c = -b.compare(nil)
+			default:
+				c = a.compare(b)
+			}
+			if c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// normalize removes the trailing items equivalent to their absence, looking
+// past trailing lists
+func (l *mavenList) normalize() {
+	for i := len(*l) - 1; i >= 0; i-- {
+		item := (*l)[i]
+		if item.isNull() {
+			*l = append((*l)[:i], (*l)[i+1:]...)
+			continue
+		}
+		if _, ok := item.(*mavenList); !ok {
+			break
+		}
+	}
+}
+
+// newMavenString returns the qualifier item of a value
+func newMavenString(value string, followedByDigit bool) mavenString {
+	value = strings.ToLower(value)
+	if s, ok := mavenShorthands[value]; ok && followedByDigit {
+		value = s
+	}
+	if a, ok := mavenAliases[value]; ok {
+		value = a
+	}
+	return mavenString(value)
+}
+
+// newMavenItem returns the number or qualifier item of a value
+func newMavenItem(digits bool, value string) mavenItem {
+	if digits {
+		return mavenInt(strings.TrimLeft(value, "0"))
+	}
+	return newMavenString(value, false)
+}
+
+// String returns the canonical representation of the items
+func (l *mavenList) String() string {
+	var b strings.Builder
+	for i, item := range *l {
+		if i > 0 {
+			if _, ok := item.(*mavenList); ok {
+				b.WriteByte('-')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+
+		switch item := item.(type) {
+		case mavenInt:
+			if item == "" {
+				b.WriteByte('0')
+			}
+			b.WriteString(string(item))
+		case mavenString:
+			b.WriteString(string(item))
+		case *mavenList:
+			b.WriteString(item.String())
+		}
+	}
+	return b.String()
+}
+
+// MavenVersion is the version of a Maven artifact, ordered the way Maven's
+// ComparableVersion orders them, e.g. 1.2.0-alpha-1 < 1.2.0-beta2 <
+// 1.2.0-M1 < 1.2.0-RC1 < 1.2.0-SNAPSHOT < 1.2.0 = 1.2.0.RELEASE < 1.2.0-sp1
+type MavenVersion struct {
+	items *mavenList
+	raw   string
+}
+
+// ParseMaven parses the version of a Maven artifact. Maven accepts any
+// string as a version, so this only accepts versions that start with a digit
+// and are made of letters and digits separated by dots and hyphens.
+func ParseMaven(in string) (*MavenVersion, error) {
+	if !mavenPattern.MatchString(in) {
+		return nil, fmt.Errorf("invalid version %q: doesn't follow the Maven version format", in)
+	}
+
+	root := &mavenList{}
+	list := root
+	stack := []*mavenList{root}
+	digits, start := false, 0
+
+	// push starts a new list in the current list
+	push := func() {
+		l := &mavenList{}
+		*list = append(*list, l)
+		list = l
+		stack = append(stack, l)
+	}
+
+	for i := 0; i < len(in); i++ {
+		c := in[i]
+		switch {
+		case c == '.':
+			*list = append(*list, newMavenItem(digits, in[start:i]))
+			start = i + 1
+
+		case c == '-':
+			*list = append(*list, newMavenItem(digits, in[start:i]))
+			start = i + 1
+			push()
+
+		case isDigit(c):
+			if !digits && i > start {
+				*list = append(*list, newMavenString(in[start:i], true))
+				start = i
+				push()
+			}
+			digits = true
+
+		default:
+			if digits && i > start {
+				*list = append(*list, newMavenItem(true, in[start:i]))
+				start = i
+				push()
+			}
+			digits = false
+		}
+	}
+	if len(in) > start {
+		*list = append(*list, newMavenItem(digits, in[start:]))
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].normalize()
+	}
+	return &MavenVersion{items: root, raw: in}, nil
+}
+
+// String returns the version as it was given, Maven versions have no
+// normalized spelling
+func (v *MavenVersion) String() string {
+	return v.raw
+}
+
+// Original returns the raw value the version was parsed from
+func (v *MavenVersion) Original() string {
+	return v.raw
+}
+
+// Canonical returns the canonical representation of the version Maven
+// compares, e.g. 1.2-snapshot for 1.2.0-SNAPSHOT
+func (v *MavenVersion) Canonical() string {
+	return v.items.String()
+}
+
+// Compare compares the versions the way Maven does
+func (v *MavenVersion) Compare(o Ordered) int {
+	ov, ok := o.(*MavenVersion)
+	if !ok {
+		return strings.Compare(v.String(), o.String())
+	}
+	return v.items.compare(ov.items)
+}
+
+// Maven is the versioning scheme of Maven artifacts, see
+// https://maven.apache.org/pom.html#version-order-specification
+var Maven Scheme = mavenScheme{}
+
+type mavenScheme struct{}
+
+func (mavenScheme) Name() string {
+	return "maven"
+}
+
+func (mavenScheme) Parse(in string) (Ordered, error) {
+	return ParseMaven(in)
+}
+
+// Increment returns the version incremented by the release type. A major,
+// minor or patch increment of a prerelease such as a SNAPSHOT releases it,
+// keeping release qualifiers such as .RELEASE. Prerelease increments start a
+// SNAPSHOT, or a qualifier such as M or RC numbered from 1 when given as the
+// identifier, and increment the number of a numbered qualifier.
+func (mavenScheme) Increment(in string, rt ReleaseType, ident string) (string, error) {
+	v, err := ParseMaven(in)
+	if err != nil {
+		return "", err
+	}
+
+	m := mavenRelease.FindStringSubmatch(in)
+	if m == nil {
+		return "", fmt.Errorf("%s can't be incremented, it doesn't start with numeric components followed by a qualifier", in)
+	}
+	nums, sep, q := strings.Split(m[1], "."), m[2], m[3]
+
+	release, _ := ParseMaven(m[1])
+	kind := v.Compare(release)
+
+	var out string
+	switch rt {
+	case Major, Minor, Patch:
+		c := int(rt)
+		if kind < 0 && zerosAfter(nums, c) {
+			out = m[1]
+			break
+		}
+
+		if nums, err = bumpComponent(nums, c); err != nil {
+			return "", err
+		}
+		out = strings.Join(nums, ".")
+		if q != "" && kind == 0 {
+			out += sep + q
+		}
+
+	case PreMajor, PreMinor, PrePatch:
+		if nums, err = bumpComponent(nums, int(rt-PreMajor)); err != nil {
+			return "", err
+		}
+		out = strings.Join(nums, ".") + "-" + mavenQualifier(ident)
+
+	case PreRelease:
+		label, lsep, n := "", "", ""
+		if p := mavenNumbered.FindStringSubmatch(q); p != nil {
+			label, lsep, n = p[1], p[2], p[3]
+		}
+
+		switch {
+		case kind >= 0:
+			if nums, err = bumpComponent(nums, int(Patch)); err != nil {
+				return "", err
+			}
+			out = strings.Join(nums, ".") + "-" + mavenQualifier(ident)
+
+		case ident == "" || newMavenString(label, true) == newMavenString(ident, true):
+			if n == "" {
+				return "", fmt.Errorf("%s has no prerelease number to increment", in)
+			}
+			next, err := strconv.ParseUint(n, 10, 64)
+			if err != nil || next == ^uint64(0) {
+				return "", fmt.Errorf("%s prerelease number out of range", in)
+			}
+			out = m[1] + sep + label + lsep + strconv.FormatUint(next+1, 10)
+
+		default:
+			out = m[1] + "-" + mavenQualifier(ident)
+		}
+
+	default:
+		return "", fmt.Errorf("%s: %w", rt, ErrUnsupportedReleaseType)
+	}
+
+	nv, err := ParseMaven(out)
+	if err != nil {
+		return "", err
+	}
+	if nv.Compare(v) <= 0 {
+		return "", fmt.Errorf("%s is later than %s", in, out)
+	}
+	return out, nil
+}
+
+// mavenQualifier returns the qualifier starting a prerelease for an
+// identifier, a SNAPSHOT when none is given
+func mavenQualifier(ident string) string {
+	switch {
+	case ident == "":
+		return "SNAPSHOT"
+	case strings.EqualFold(ident, "snapshot"):
+		return ident
+	}
+	return ident + "1"
+}
+
+// bumpComponent increments a numeric component, resetting the ones after it
+func bumpComponent(nums []string, c int) ([]string, error) {
+	for len(nums) <= c {
+		nums = append(nums, "0")
+	}
+
+	n, err := strconv.ParseUint(nums[c], 10, 64)
+	if err != nil || n == ^uint64(0) {
+		return nil, errors.New("version component out of range")
+	}
+	nums[c] = strconv.FormatUint(n+1, 10)
+
+	for i := c + 1; i < len(nums); i++ {
+		nums[i] = "0"
+	}
+	return nums, nil
+}
+
+// zerosAfter reports whether the numeric components after a component are
+// all zero
+func zerosAfter(nums []string, c int) bool {
+	for i := c + 1; i < len(nums); i++ {
+		if strings.TrimLeft(nums[i], "0") != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseMaven(t *testing.T) {
+	tests := []struct {
+		version   string
+		canonical string
+		valid     bool
+	}{
+		{"1.2.0", "1.2", true},
+		{"1.2.0-SNAPSHOT", "1.2-snapshot", true},
+		{"1.2.0.RELEASE", "1.2", true},
+		{"1.2.0-M1", "1.2-milestone-1", true},
+		{"1.2.0-rc-2", "1.2-rc-2", true},
+		{"1.2.0-beta3", "1.2-beta-3", true},
+		{"1.0-alpha", "1-alpha", true},
+		{"1.0.0.Final", "1", true},
+		{"v1.2.0", "", false},
+		{"1..2", "", false},
+		{"1.2.", "", false},
+		{"1.2_0", "", false},
+		{"", "", false},
+	}
+
+	for _, tc := range tests {
+		v, err := ParseMaven(tc.version)
+		if !tc.valid {
+			if err == nil {
+				t.Fatalf("expected version %s to be invalid", tc.version)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("error for version %s: %s", tc.version, err)
+		}
+
+		if v.Canonical() != tc.canonical || v.String() != tc.version {
+			t.Fatalf("expected version %s to be canonically %s, but got %s", tc.version, tc.canonical, v.Canonical())
+		}
+	}
+}
+
+func TestMavenCompare(t *testing.T) {
+	// from the version order specification of Maven
+	ordered := []string{
+		"1-alpha-1",
+		"1-alpha-2",
+		"1-beta-1",
+		"1-M1",
+		"1-rc-1",
+		"1-cr-2",
+		"1-SNAPSHOT",
+		"1",
+		"1-sp",
+		"1-foo",
+		"1-1-snapshot",
+		"1-1",
+		"1.1",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		a, _ := ParseMaven(ordered[i])
+		b, _ := ParseMaven(ordered[i+1])
+		if a == nil || b == nil {
+			t.Fatalf("expected %s and %s to be valid", ordered[i], ordered[i+1])
+		}
+		if a.Compare(b) >= 0 || b.Compare(a) <= 0 {
+			t.Fatalf("expected %s to be lower than %s", ordered[i], ordered[i+1])
+		}
+	}
+
+	equal := [][2]string{
+		{"1", "1.0.0"},
+		{"1.2.0.RELEASE", "1.2"},
+		{"1-ga", "1-final"},
+		{"1.0-a1", "1.0-alpha-1"},
+		{"1.0-RC1", "1.0-cr1"},
+		{"1.0-SNAPSHOT", "1-snapshot"},
+	}
+	for _, tc := range equal {
+		a, _ := ParseMaven(tc[0])
+		b, _ := ParseMaven(tc[1])
+		if a.Compare(b) != 0 {
+			t.Fatalf("expected %s and %s to be equal", tc[0], tc[1])
+		}
+	}
+
+	vs := SortedListBy(Maven, []string{"1.2.0", "1.2.0-SNAPSHOT", "1.2.0-M1", "1.10.0", "1.2.0.RELEASE", "release-1"})
+	expected := []string{"1.2.0-M1", "1.2.0-SNAPSHOT", "1.2.0", "1.2.0.RELEASE", "1.10.0"}
+	if len(vs) != len(expected) {
+		t.Fatalf("expected %v, but got %v", expected, vs)
+	}
+	for i := range vs {
+		if vs[i] != expected[i] {
+			t.Fatalf("expected %v, but got %v", expected, vs)
+		}
+	}
+}
+
+func TestMavenIncrement(t *testing.T) {
+	tests := []struct {
+		version  string
+		rt       ReleaseType
+		ident    string
+		expected string
+		err      bool
+	}{
+		{"1.2.0-SNAPSHOT", Patch, "", "1.2.0", false},
+		{"1.2.0-SNAPSHOT", Minor, "", "1.2.0", false},
+		{"1.2.3-SNAPSHOT", Minor, "", "1.3.0", false},
+		{"1.2.0", Patch, "", "1.2.1", false},
+		{"1.2", Patch, "", "1.2.1", false},
+		{"1.2.0.RELEASE", Minor, "", "1.3.0.RELEASE", false},
+		{"1.2.0-sp1", Patch, "", "1.2.1", false},
+		{"2.0.0-M3", Major, "", "2.0.0", false},
+		{"1.2.0", PrePatch, "", "1.2.1-SNAPSHOT", false},
+		{"1.2.0", PreMinor, "", "1.3.0-SNAPSHOT", false},
+		{"1.2.0", PreMajor, "M", "2.0.0-M1", false},
+		{"1.2.0", PreRelease, "", "1.2.1-SNAPSHOT", false},
+		{"1.2.0", PreRelease, "RC", "1.2.1-RC1", false},
+		{"1.2.0-M1", PreRelease, "", "1.2.0-M2", false},
+		{"1.2.0-M1", PreRelease, "m", "1.2.0-M2", false},
+		{"1.2.0-rc-9", PreRelease, "", "1.2.0-rc-10", false},
+		{"1.2.0-M2", PreRelease, "RC", "1.2.0-RC1", false},
+		{"1.2.0-RC1", PreRelease, "M", "", true},
+		{"1.2.0-SNAPSHOT", PreRelease, "", "", true},
+		{"1.2.0", Build, "", "", true},
+		{"1-1", Patch, "", "", true},
+	}
+
+	for _, tc := range tests {
+		v, err := Maven.Increment(tc.version, tc.rt, tc.ident)
+		if tc.err {
+			if err == nil {
+				t.Fatalf("expected %s %s increment with %q to fail, but got %s", tc.version, tc.rt, tc.ident, v)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("error for %s %s increment with %q: %s", tc.version, tc.rt, tc.ident, err)
+		}
+
+		if v != tc.expected {
+			t.Fatalf("expected %s %s increment with %q to be %s, but got %s", tc.version, tc.rt, tc.ident, tc.expected, v)
+		}
+	}
+
+	if _, err := Maven.Increment("1.2.0", Build, ""); !errors.Is(err, ErrUnsupportedReleaseType) {
+		t.Fatalf("expected build increment to be unsupported, but got: %v", err)
+	}
+}
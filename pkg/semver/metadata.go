@@ -0,0 +1,64 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ValidMetadata returns an error describing why build metadata, such as
+// "build.123" or "sha.abc123", isn't valid, or nil if it's valid
+func ValidMetadata(meta string) error {
+	if d := identifiers(meta, meta, 0, "build metadata", false); d != nil {
+		return fmt.Errorf("invalid build metadata %q: %s at offset %d", meta, d.Reason, d.Offset)
+	}
+	return nil
+}
+
+// SetMetadata returns the version with its build metadata replaced, or
+// stripped when meta is empty. The result keeps the "v" prefix when the input
+// has one.
+func SetMetadata(in string, meta string) (string, error) {
+	v, err := semver.NewVersion(in)
+	if err != nil {
+		return "", err
+	}
+
+	if meta != "" {
+		if err := ValidMetadata(meta); err != nil {
+			return "", err
+		}
+	}
+
+	nv, err := v.SetMetadata(meta)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.HasPrefix(in, "v") {
+		return "v" + nv.String(), nil
+	}
+	return nv.String(), nil
+}
+
+// AppendMetadata returns the version with identifiers appended to its build
+// metadata, e.g. 1.2.3+build.5 with sha.abc123 gives 1.2.3+build.5.sha.abc123
+func AppendMetadata(in string, meta string) (string, error) {
+	v, err := semver.NewVersion(in)
+	if err != nil {
+		return "", err
+	}
+
+	if m := v.Metadata(); m != "" && meta != "" {
+		meta = m + "." + meta
+	} else if meta == "" {
+		meta = m
+	}
+	return SetMetadata(in, meta)
+}
+
+// StripMetadata returns the version without build metadata
+func StripMetadata(in string) (string, error) {
+	return SetMetadata(in, "")
+}
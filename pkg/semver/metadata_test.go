@@ -0,0 +1,55 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestMetadata(t *testing.T) {
+	tests := []struct {
+		version  string
+		meta     string
+		set      string
+		appended string
+		stripped string
+	}{
+		{"1.2.3", "build.123", "1.2.3+build.123", "1.2.3+build.123", "1.2.3"},
+		{"1.2.3+build.5", "sha.abc123", "1.2.3+sha.abc123", "1.2.3+build.5.sha.abc123", "1.2.3"},
+		{"v1.2.3-rc.1+build.5", "sha.abc123", "v1.2.3-rc.1+sha.abc123", "v1.2.3-rc.1+build.5.sha.abc123", "v1.2.3-rc.1"},
+		{"1.2+build.5", "", "1.2.0", "1.2.0+build.5", "1.2.0"},
+	}
+
+	for _, tc := range tests {
+		set, err := SetMetadata(tc.version, tc.meta)
+		if err != nil {
+			t.Fatalf("error setting metadata %q of %s: %s", tc.meta, tc.version, err)
+		}
+
+		appended, err := AppendMetadata(tc.version, tc.meta)
+		if err != nil {
+			t.Fatalf("error appending metadata %q to %s: %s", tc.meta, tc.version, err)
+		}
+
+		stripped, err := StripMetadata(tc.version)
+		if err != nil {
+			t.Fatalf("error stripping metadata of %s: %s", tc.version, err)
+		}
+
+		if set != tc.set || appended != tc.appended || stripped != tc.stripped {
+			t.Fatalf("expected %s with %q to set %s, append %s and strip %s, but got %s, %s and %s", tc.version, tc.meta, tc.set, tc.appended, tc.stripped, set, appended, stripped)
+		}
+	}
+
+	for _, meta := range []string{"build..5", "sha/abc", ".build", "build."} {
+		if _, err := SetMetadata("1.2.3", meta); err == nil {
+			t.Fatalf("expected metadata %q to be invalid", meta)
+		}
+
+		if err := ValidMetadata(meta); err == nil {
+			t.Fatalf("expected metadata %q to be invalid", meta)
+		}
+	}
+
+	if _, err := SetMetadata("1.2.beta", "build.1"); err == nil {
+		t.Fatal("expected an invalid version to fail")
+	}
+}
@@ -0,0 +1,518 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pep440Pattern is the version pattern of PEP 440, see
+// https://peps.python.org/pep-0440/#appendix-b-parsing-version-strings-with-regular-expressions
+var pep440Pattern = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<prel>a|b|c|rc|alpha|beta|pre|preview)[-_.]?(?P<pren>[0-9]+)?)?` +
+	`(?:-(?P<postn1>[0-9]+)|[-_.]?(?P<postl>post|rev|r)[-_.]?(?P<postn2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<devl>dev)[-_.]?(?P<devn>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?` +
+	`\s*$`)
+
+// pep440Labels maps the spellings of prerelease labels to their normalized
+// form
+var pep440Labels = map[string]string{
+	"a":       "a",
+	"alpha":   "a",
+	"b":       "b",
+	"beta":    "b",
+	"c":       "rc",
+	"rc":      "rc",
+	"pre":     "rc",
+	"preview": "rc",
+}
+
+// pep440Ranks orders the normalized prerelease labels
+var pep440Ranks = map[string]int{"a": 0, "b": 1, "rc": 2}
+
+// PEP440Version is a Python package version, see
+// https://peps.python.org/pep-0440/
+type PEP440Version struct {
+	// Epoch is the version epoch, 0 unless given as N!
+	Epoch int
+	// Release are the numeric release components, e.g. 1, 2 and 0 for 1.2.0
+	Release []int
+	// PreLabel is the normalized prerelease label, one of a, b or rc, or an
+	// empty string when the version isn't a prerelease
+	PreLabel string
+	// Pre is the number of the prerelease
+	Pre int
+	// Post is the number of the post-release, or -1 when there is none
+	Post int
+	// Dev is the number of the developmental release, or -1 when there is
+	// none
+	Dev int
+	// Local is the normalized local version label, e.g. ubuntu.1
+	Local string
+
+	raw string
+}
+
+// ParsePEP440 parses a raw version value following PEP 440. Alternative
+// spellings such as 1.2.0-RC.1, 1.2.0-1 or 1.2.0-dev3 are accepted and
+// normalized.
+func ParsePEP440(in string) (*PEP440Version, error) {
+	m := pep440Pattern.FindStringSubmatch(in)
+	if m == nil {
+		return nil, fmt.Errorf("invalid version %q: doesn't follow PEP 440", in)
+	}
+
+	group := func(name string) string {
+		return m[pep440Pattern.SubexpIndex(name)]
+	}
+	number := func(name string) (int, error) {
+		s := group(name)
+		if s == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid version %q: %s out of range", in, name)
+		}
+		return n, nil
+	}
+
+	v := &PEP440Version{Post: -1, Dev: -1, raw: in}
+
+	var err error
+	if v.Epoch, err = number("epoch"); err != nil {
+		return nil, err
+	}
+
+	for _, p := range strings.Split(group("release"), ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q: release out of range", in)
+		}
+		v.Release = append(v.Release, n)
+	}
+
+	if l := group("prel"); l != "" {
+		v.PreLabel = pep440Labels[strings.ToLower(l)]
+		if v.Pre, err = number("pren"); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case group("postn1") != "":
+		if v.Post, err = number("postn1"); err != nil {
+			return nil, err
+		}
+	case group("postl") != "":
+		if v.Post, err = number("postn2"); err != nil {
+			return nil, err
+		}
+	}
+
+	if group("devl") != "" {
+		if v.Dev, err = number("devn"); err != nil {
+			return nil, err
+		}
+	}
+
+	v.Local = strings.NewReplacer("-", ".", "_", ".").Replace(strings.ToLower(group("local")))
+	return v, nil
+}
+
+// String returns the normalized representation of the version
+func (v *PEP440Version) String() string {
+	var b strings.Builder
+	if v.Epoch != 0 {
+		fmt.Fprintf(&b, "%d!", v.Epoch)
+	}
+
+	for i, n := range v.Release {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(strconv.Itoa(n))
+	}
+
+	if v.PreLabel != "" {
+		fmt.Fprintf(&b, "%s%d", v.PreLabel, v.Pre)
+	}
+	if v.Post >= 0 {
+		fmt.Fprintf(&b, ".post%d", v.Post)
+	}
+	if v.Dev >= 0 {
+		fmt.Fprintf(&b, ".dev%d", v.Dev)
+	}
+	if v.Local != "" {
+		b.WriteString("+" + v.Local)
+	}
+	return b.String()
+}
+
+// Original returns the raw value the version was parsed from
+func (v *PEP440Version) Original() string {
+	if v.raw == "" {
+		return v.String()
+	}
+	return v.raw
+}
+
+// IsPrerelease reports whether the version is a prerelease or a
+// developmental release
+func (v *PEP440Version) IsPrerelease() bool {
+	return v.PreLabel != "" || v.Dev >= 0
+}
+
+// Compare compares the versions following the ordering of PEP 440, where
+// 1.0.dev0 < 1.0a1.dev0 < 1.0a1 < 1.0rc1 < 1.0 < 1.0.post1.dev0 < 1.0.post1
+func (v *PEP440Version) Compare(o Ordered) int {
+	ov, ok := o.(*PEP440Version)
+	if !ok {
+		return strings.Compare(v.String(), o.String())
+	}
+
+	if c := compareInts(v.Epoch, ov.Epoch); c != 0 {
+		return c
+	}
+
+	// release components compare as if padded with zeros
+	for i := 0; i < len(v.Release) || i < len(ov.Release); i++ {
+		a, b := 0, 0
+		if i < len(v.Release) {
+			a = v.Release[i]
+		}
+		if i < len(ov.Release) {
+			b = ov.Release[i]
+		}
+		if c := compareInts(a, b); c != 0 {
+			return c
+		}
+	}
+
+	ar, an := v.preKey()
+	br, bn := ov.preKey()
+	if c := compareInts(ar, br); c != 0 {
+		return c
+	}
+	if c := compareInts(an, bn); c != 0 {
+		return c
+	}
+
+	if c := compareInts(v.Post, ov.Post); c != 0 {
+		return c
+	}
+
+	if c := compareInts(v.devKey(), ov.devKey()); c != 0 {
+		return c
+	}
+	return compareLocal(v.Local, ov.Local)
+}
+
+// preKey returns the rank and number the prerelease segment orders by. A
+// developmental release of a final release comes before its prereleases,
+// which come before the final release.
+func (v *PEP440Version) preKey() (int, int) {
+	switch {
+	case v.PreLabel != "":
+		return pep440Ranks[v.PreLabel], v.Pre
+	case v.Post < 0 && v.Dev >= 0:
+		return -1, 0
+	}
+	return len(pep440Ranks), 0
+}
+
+// devKey returns the number the developmental segment orders by, ordering
+// versions without one last
+func (v *PEP440Version) devKey() int {
+	if v.Dev < 0 {
+		return int(^uint(0) >> 1)
+	}
+	return v.Dev
+}
+
+// compareLocal compares local version labels segment by segment. Numeric
+// segments are greater than alphanumeric ones, and a version without a label
+// is lower than one with a label.
+func compareLocal(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return -1
+	case b == "":
+		return 1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aerr := strconv.Atoi(as[i])
+		bn, berr := strconv.Atoi(bs[i])
+		var c int
+		switch {
+		case aerr == nil && berr == nil:
+			c = compareInts(an, bn)
+		case aerr == nil:
+			c = 1
+		case berr == nil:
+			c = -1
+		default:
+			c = strings.Compare(as[i], bs[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(as), len(bs))
+}
+
+// compareInts returns -1, 0 or 1 depending on whether a is lower than, equal
+// to or greater than b
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// PEP440 is the versioning scheme of Python packages, see
+// https://peps.python.org/pep-0440/
+var PEP440 Scheme = pep440Scheme{}
+
+type pep440Scheme struct{}
+
+func (pep440Scheme) Name() string {
+	return "pep440"
+}
+
+func (pep440Scheme) Parse(in string) (Ordered, error) {
+	return ParsePEP440(in)
+}
+
+// Increment returns the version incremented by the release type. The
+// identifier selects the segment of prerelease increments: a, b or rc for a
+// prerelease, or dev for a developmental release. Increments drop the local
+// version label.
+func (pep440Scheme) Increment(in string, rt ReleaseType, ident string) (string, error) {
+	v, err := ParsePEP440(in)
+	if err != nil {
+		return "", err
+	}
+	v.Local = ""
+
+	label := strings.ToLower(ident)
+	if label != "" && label != "dev" {
+		l, ok := pep440Labels[label]
+		if !ok {
+			return "", fmt.Errorf("invalid identifier %q, must be one of a, b, rc or dev", ident)
+		}
+		label = l
+	}
+
+	switch rt {
+	case Major, Minor, Patch:
+		v.release(int(rt))
+
+	case PreMajor, PreMinor, PrePatch:
+		if label == "" {
+			return "", errors.New("a prerelease increment requires an identifier of a, b, rc or dev")
+		}
+		v.PreLabel, v.Post, v.Dev = "", -1, -1
+		v.bump(int(rt - PreMajor))
+		v.start(label)
+
+	case PreRelease:
+		if err := v.prerelease(label); err != nil {
+			return "", err
+		}
+
+	default:
+		return "", fmt.Errorf("%s: %w", rt, ErrUnsupportedReleaseType)
+	}
+	return v.String(), nil
+}
+
+// release turns the version into the final release of a component,
+// incrementing the component unless the version is a prerelease of that
+// release
+func (v *PEP440Version) release(component int) {
+	pre := v.IsPrerelease() && v.Post < 0
+	v.PreLabel, v.Post, v.Dev = "", -1, -1
+	v.pad(component + 1)
+
+	if pre {
+		zeros := true
+		for _, n := range v.Release[component+1:] {
+			zeros = zeros && n == 0
+		}
+		if zeros {
+			return
+		}
+	}
+	v.bump(component)
+}
+
+// bump increments a release component, resetting the ones after it
+func (v *PEP440Version) bump(component int) {
+	v.pad(component + 1)
+	v.Release[component]++
+	for i := component + 1; i < len(v.Release); i++ {
+		v.Release[i] = 0
+	}
+}
+
+// pad adds zero release components up to a length
+func (v *PEP440Version) pad(n int) {
+	for len(v.Release) < n {
+		v.Release = append(v.Release, 0)
+	}
+}
+
+// start starts the first prerelease or developmental release of a label
+func (v *PEP440Version) start(label string) {
+	if label == "dev" {
+		v.Dev = 0
+		return
+	}
+	v.PreLabel, v.Pre, v.Dev = label, 0, -1
+}
+
+// prerelease moves the version to the next prerelease or developmental
+// release of a label, or of its current segment when no label is given
+func (v *PEP440Version) prerelease(label string) error {
+	switch {
+	case v.Dev >= 0 && (label == "" || label == "dev"):
+		v.Dev++
+
+	case v.PreLabel != "" && label == "":
+		v.Pre++
+		v.Dev = -1
+
+	case v.PreLabel != "" && label == "dev":
+		v.Pre++
+		v.Dev = 0
+
+	case v.PreLabel != "" && v.Post < 0:
+		switch c := compareInts(pep440Ranks[label], pep440Ranks[v.PreLabel]); {
+		case c < 0:
+			return fmt.Errorf("%s is a later prerelease than %s", v, label)
+		case c > 0:
+			v.start(label)
+		case v.Dev >= 0:
+			// the prerelease the developmental release leads to
+			v.Dev = -1
+		default:
+			v.Pre++
+		}
+
+	case v.Dev >= 0 && v.Post < 0:
+		// the developmental release of a final release precedes its
+		// prereleases
+		v.start(label)
+
+	default:
+		if label == "" {
+			return errors.New("a prerelease increment of a release requires an identifier of a, b, rc or dev")
+		}
+		v.PreLabel, v.Post, v.Dev = "", -1, -1
+		v.bump(int(Patch))
+		v.start(label)
+	}
+	return nil
+}
+
+// PEP440ToSemVer converts a PEP 440 version to a semantic version for
+// packages published to both ecosystems, preserving their order. Prereleases
+// become alpha, beta or rc prerelease identifiers, and a post-release of a
+// prerelease a post identifier after them, e.g. 1.2.0rc1.post2 becomes
+// 1.2.0-rc.1.post.2. Local version labels are kept in the build metadata, so
+// they don't take part in the order. Developmental releases, post-releases of
+// final releases and versions with an epoch or more than three release
+// components can't be converted, since no semantic version sorts the same.
+func PEP440ToSemVer(in string) (string, error) {
+	v, err := ParsePEP440(in)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case v.Epoch != 0:
+		return "", fmt.Errorf("%s has an epoch, which semantic versions can't express", v)
+	case v.Dev >= 0:
+		return "", fmt.Errorf("%s is a developmental release, which has no semantic version sorting the same", v)
+	case v.Post >= 0 && v.PreLabel == "":
+		return "", fmt.Errorf("%s is a post-release of a final release, which has no semantic version sorting the same", v)
+	}
+
+	release := append([]int{}, v.Release...)
+	for len(release) > 3 && release[len(release)-1] == 0 {
+		release = release[:len(release)-1]
+	}
+	if len(release) > 3 {
+		return "", fmt.Errorf("%s has more than three release components", v)
+	}
+	for len(release) < 3 {
+		release = append(release, 0)
+	}
+
+	s := fmt.Sprintf("%d.%d.%d", release[0], release[1], release[2])
+	if v.PreLabel != "" {
+		label := map[string]string{"a": "alpha", "b": "beta", "rc": "rc"}[v.PreLabel]
+		s += "-" + label + "." + strconv.Itoa(v.Pre)
+		if v.Post >= 0 {
+			s += ".post." + strconv.Itoa(v.Post)
+		}
+	}
+
+	if v.Local != "" {
+		s += "+" + v.Local
+	}
+	return s, nil
+}
+
+// semverPrerelease matches the prereleases of semantic versions that have a
+// PEP 440 equivalent sorting the same
+var semverPrerelease = regexp.MustCompile(`(?i)^(alpha|a|beta|b|rc|c)\.?([0-9]+)?(?:\.post\.([0-9]+))?$`)
+
+// SemVerToPEP440 converts a semantic version to a PEP 440 version for
+// packages published to both ecosystems, reversing PEP440ToSemVer. The
+// prerelease must be an alpha, beta or rc identifier followed by an optional
+// number and post identifier, e.g. 1.2.0-rc.1 becomes 1.2.0rc1, and the build
+// metadata becomes the local version label. Other prereleases, such as dev.3,
+// can't be converted since no PEP 440 version sorts the same.
+func SemVerToPEP440(in string) (string, error) {
+	v, err := NewVersion(in)
+	if err != nil {
+		return "", err
+	}
+
+	s := fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+	if pre := strings.Join(v.Prerelease(), "."); pre != "" {
+		m := semverPrerelease.FindStringSubmatch(pre)
+		if m == nil {
+			return "", fmt.Errorf("prerelease %q of %s has no PEP 440 equivalent", pre, in)
+		}
+
+		s += m[1] + m[2]
+		if m[3] != "" {
+			s += ".post" + m[3]
+		}
+	}
+
+	if meta := v.Metadata(); meta != "" {
+		s += "+" + meta
+	}
+
+	p, err := ParsePEP440(s)
+	if err != nil {
+		return "", fmt.Errorf("%s has no PEP 440 equivalent", in)
+	}
+	return p.String(), nil
+}
@@ -0,0 +1,236 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePEP440(t *testing.T) {
+	tests := []struct {
+		version    string
+		normalized string
+		valid      bool
+	}{
+		{"1.2.0", "1.2.0", true},
+		{"v1.2.0rc1", "1.2.0rc1", true},
+		{"1.2.0-RC.1", "1.2.0rc1", true},
+		{"1.2.0alpha", "1.2.0a0", true},
+		{"1.2.0.post2", "1.2.0.post2", true},
+		{"1.2.0-2", "1.2.0.post2", true},
+		{"1.2.0.dev3", "1.2.0.dev3", true},
+		{"1.2.0_dev", "1.2.0.dev0", true},
+		{"1!2.0b2.post1.dev4", "1!2.0b2.post1.dev4", true},
+		{"1.2.0+Ubuntu-1", "1.2.0+ubuntu.1", true},
+		{"1.2.x", "", false},
+		{"1.2.0-beta.foo", "", false},
+		{"1.2.0+", "", false},
+		{"", "", false},
+	}
+
+	for _, tc := range tests {
+		v, err := ParsePEP440(tc.version)
+		if !tc.valid {
+			if err == nil {
+				t.Fatalf("expected version %s to be invalid", tc.version)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("error for version %s: %s", tc.version, err)
+		}
+
+		if v.String() != tc.normalized {
+			t.Fatalf("expected version %s to normalize to %s, but got %s", tc.version, tc.normalized, v.String())
+		}
+	}
+}
+
+func TestPEP440Sort(t *testing.T) {
+	expected := []string{
+		"1.0.dev0",
+		"1.0a1.dev0",
+		"1.0a1",
+		"1.0b1",
+		"1.0rc1",
+		"1.0",
+		"1.0+local.1",
+		"1.0+local.2",
+		"1.0.post1.dev0",
+		"1.0.post1",
+		"1.0.1",
+		"1!0.1",
+	}
+
+	in := make([]string, len(expected))
+	for i, v := range expected {
+		in[len(in)-1-i] = v
+	}
+
+	vs := SortedBy(PEP440, in)
+	for i, v := range vs {
+		if v.Original() != expected[i] {
+			t.Fatalf("expected versions to sort as %v, but got %s at %d", expected, v.Original(), i)
+		}
+	}
+
+	a, _ := ParsePEP440("1.0")
+	b, _ := ParsePEP440("1.0.0")
+	if a.Compare(b) != 0 {
+		t.Fatal("expected 1.0 and 1.0.0 to be equal")
+	}
+}
+
+func TestPEP440Increment(t *testing.T) {
+	tests := []struct {
+		version  string
+		rt       ReleaseType
+		ident    string
+		expected string
+		err      bool
+	}{
+		{"1.2.3", Major, "", "2.0.0", false},
+		{"1.2", Minor, "", "1.3", false},
+		{"1.2", Patch, "", "1.2.1", false},
+		{"1.2.3+local", Patch, "", "1.2.4", false},
+		{"2.0.0rc1", Major, "", "2.0.0", false},
+		{"1.2.3.dev1", Patch, "", "1.2.3", false},
+		{"1.2.3.post1", Patch, "", "1.2.4", false},
+		{"1.2.3", PreMajor, "rc", "2.0.0rc0", false},
+		{"1.2.3", PreMinor, "beta", "1.3.0b0", false},
+		{"1.2.3", PrePatch, "dev", "1.2.4.dev0", false},
+		{"1.2.3", PreMajor, "", "", true},
+		{"1.2.3", PreRelease, "a", "1.2.4a0", false},
+		{"1.2.3", PreRelease, "", "", true},
+		{"1.2.0rc1", PreRelease, "", "1.2.0rc2", false},
+		{"1.2.0rc1", PreRelease, "rc", "1.2.0rc2", false},
+		{"1.2.0a3", PreRelease, "b", "1.2.0b0", false},
+		{"1.2.0rc1", PreRelease, "a", "", true},
+		{"1.2.0rc1", PreRelease, "dev", "1.2.0rc2.dev0", false},
+		{"1.2.0rc2.dev0", PreRelease, "dev", "1.2.0rc2.dev1", false},
+		{"1.2.0rc2.dev0", PreRelease, "rc", "1.2.0rc2", false},
+		{"1.2.0.dev3", PreRelease, "", "1.2.0.dev4", false},
+		{"1.2.0.dev3", PreRelease, "a", "1.2.0a0", false},
+		{"1.2.0.post1", PreRelease, "rc", "1.2.1rc0", false},
+		{"1.2.0", PreRelease, "gamma", "", true},
+		{"1.2.0", Build, "", "", true},
+	}
+
+	for _, tc := range tests {
+		v, err := PEP440.Increment(tc.version, tc.rt, tc.ident)
+		if tc.err {
+			if err == nil {
+				t.Fatalf("expected %s %s increment with %q to fail, but got %s", tc.version, tc.rt, tc.ident, v)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("error for %s %s increment with %q: %s", tc.version, tc.rt, tc.ident, err)
+		}
+
+		if v != tc.expected {
+			t.Fatalf("expected %s %s increment with %q to be %s, but got %s", tc.version, tc.rt, tc.ident, tc.expected, v)
+		}
+	}
+
+	if _, err := PEP440.Increment("1.2.0", Build, ""); !errors.Is(err, ErrUnsupportedReleaseType) {
+		t.Fatalf("expected build increment to be unsupported, but got: %v", err)
+	}
+}
+
+func TestPEP440Conversion(t *testing.T) {
+	tests := []struct {
+		pep440 string
+		semver string
+	}{
+		{"1.2.0", "1.2.0"},
+		{"1.2", "1.2.0"},
+		{"1.2.0a1", "1.2.0-alpha.1"},
+		{"1.2.0a", "1.2.0-alpha.0"},
+		{"1.2.0b2", "1.2.0-beta.2"},
+		{"1.2.0rc1", "1.2.0-rc.1"},
+		{"1.2.0rc1.post2", "1.2.0-rc.1.post.2"},
+		{"1.2.0rc1.post2+ubuntu.1", "1.2.0-rc.1.post.2+ubuntu.1"},
+		{"1.2.0+ubuntu.1", "1.2.0+ubuntu.1"},
+	}
+
+	for _, tc := range tests {
+		s, err := PEP440ToSemVer(tc.pep440)
+		if err != nil {
+			t.Fatalf("error converting %s to semver: %s", tc.pep440, err)
+		}
+		if s != tc.semver {
+			t.Fatalf("expected %s to convert to %s, but got %s", tc.pep440, tc.semver, s)
+		}
+
+		p, err := SemVerToPEP440(s)
+		if err != nil {
+			t.Fatalf("error converting %s to PEP 440: %s", s, err)
+		}
+		expected, _ := ParsePEP440(tc.pep440)
+		if v, _ := ParsePEP440(p); v == nil || v.Compare(expected) != 0 {
+			t.Fatalf("expected %s to convert back to %s, but got %s", s, expected, p)
+		}
+	}
+
+	for _, in := range []string{"1!1.0", "1.2.3.4", "1.x", "1.2.0.dev3", "1.2.0a1.dev2", "1.2.0.post2", "1.2.0rc1.post2.dev1"} {
+		if s, err := PEP440ToSemVer(in); err == nil {
+			t.Fatalf("expected %s to have no semver equivalent, but got %s", in, s)
+		}
+	}
+
+	for _, in := range []string{"1.2.3-1", "1.2.3-foo", "1.2.3-rc.1.foo", "1.2.3-dev.3", "1.2.3-alpha.1.dev.2", "1.x"} {
+		if p, err := SemVerToPEP440(in); err == nil {
+			t.Fatalf("expected %s to have no PEP 440 equivalent, but got %s", in, p)
+		}
+	}
+
+	if p, _ := SemVerToPEP440("v1.2.3-RC1"); p != "1.2.3rc1" {
+		t.Fatalf("expected v1.2.3-RC1 to convert to 1.2.3rc1, but got %s", p)
+	}
+}
+
+func TestPEP440ConversionOrder(t *testing.T) {
+	// in PEP 440 order
+	ordered := []string{
+		"1.1.9",
+		"1.2.0a1",
+		"1.2.0a1.post1",
+		"1.2.0a1.post2",
+		"1.2.0a2",
+		"1.2.0a10",
+		"1.2.0b1",
+		"1.2.0rc1",
+		"1.2.0rc1.post1",
+		"1.2.0rc2",
+		"1.2.0",
+		"1.2.1a1",
+		"1.10.0",
+	}
+
+	converted := make([]*Version, len(ordered))
+	for i, in := range ordered {
+		s, err := PEP440ToSemVer(in)
+		if err != nil {
+			t.Fatalf("error converting %s to semver: %s", in, err)
+		}
+		if converted[i], err = NewVersion(s); err != nil {
+			t.Fatalf("error parsing %s: %s", s, err)
+		}
+
+		p, err := SemVerToPEP440(s)
+		if err != nil {
+			t.Fatalf("error converting %s to PEP 440: %s", s, err)
+		}
+		if p != in {
+			t.Fatalf("expected %s to convert back to %s, but got %s", s, in, p)
+		}
+	}
+
+	for i := 1; i < len(converted); i++ {
+		if !converted[i-1].LessThan(converted[i]) {
+			t.Fatalf("expected %s to sort before %s like %s before %s", converted[i-1], converted[i], ordered[i-1], ordered[i])
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ErrNotPseudoVersion is returned when a version isn't a Go module pseudo-version
+var ErrNotPseudoVersion = errors.New("not a pseudo-version")
+
+// pseudoTimeFormat is the layout of the timestamp of a pseudo-version
+const pseudoTimeFormat = "20060102150405"
+
+// pseudoVersion matches the three forms of pseudo-versions, see
+// https://go.dev/ref/mod#pseudo-versions
+var pseudoVersion = regexp.MustCompile(`^v[0-9]+\.(0\.0-|[0-9]+\.[0-9]+-([^+]*\.)?0\.)[0-9]{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// PseudoVersion is a parsed Go module pseudo-version, such as
+// v0.0.0-20220919091848-fb04ddd9f9c8
+type PseudoVersion struct {
+	// Version is the pseudo-version
+	Version string
+	// Base is the tagged version the pseudo-version is based on, e.g. v1.2.3
+	// for v1.2.4-0.20220919091848-fb04ddd9f9c8, or empty when there is none
+	Base string
+	// Time is the commit time of the revision
+	Time time.Time
+	// Revision is the 12 character commit hash prefix
+	Revision string
+	// Build is the build metadata, such as incompatible
+	Build string
+}
+
+// IsPseudoVersion reports whether a version is a Go module pseudo-version
+func IsPseudoVersion(in string) bool {
+	return strings.Count(in, "-") >= 2 && pseudoVersion.MatchString(in) && Diagnose(in, Loose) == nil
+}
+
+// ParsePseudoVersion parses a Go module pseudo-version into the version it is
+// based on, the commit time and the revision
+func ParsePseudoVersion(in string) (*PseudoVersion, error) {
+	if !IsPseudoVersion(in) {
+		return nil, fmt.Errorf("%s: %w", in, ErrNotPseudoVersion)
+	}
+
+	p := &PseudoVersion{Version: in}
+	v := in
+	if i := strings.Index(v, "+"); i >= 0 {
+		v, p.Build = v[:i], v[i+1:]
+	}
+
+	j := strings.LastIndex(v, "-")
+	v, p.Revision = v[:j], v[j+1:]
+
+	// vX.Y.(Z+1)-0.timestamp or vX.Y.Z-pre.0.timestamp, otherwise
+	// vX.0.0-timestamp
+	var ts string
+	i := strings.Index(v, "-")
+	if k := strings.LastIndex(v, "."); k > i {
+		p.Base, ts = v[:k], v[k+1:]
+	} else {
+		p.Base, ts = "", v[i+1:]
+	}
+
+	t, err := time.Parse(pseudoTimeFormat, ts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid timestamp: %w", in, ErrNotPseudoVersion)
+	}
+	p.Time = t
+
+	switch {
+	case p.Base == "":
+	case strings.HasSuffix(p.Base, "-0"):
+		// the release the patch version was incremented from
+		sv, err := semver.NewVersion(strings.TrimSuffix(p.Base, "-0"))
+		if err != nil || sv.Patch() == 0 {
+			return nil, fmt.Errorf("%s: invalid base version: %w", in, ErrNotPseudoVersion)
+		}
+		p.Base = fmt.Sprintf("v%d.%d.%d", sv.Major(), sv.Minor(), sv.Patch()-1)
+	default:
+		p.Base = strings.TrimSuffix(p.Base, ".0")
+	}
+	return p, nil
+}
+
+// NewPseudoVersion returns the Go module pseudo-version of a revision
+// committed at a time. The base is the highest tagged version, with a "v"
+// prefix, that is an ancestor of the revision, or empty when there is none,
+// in which case the major version, such as v2, is used and defaults to v0.
+func NewPseudoVersion(major string, base string, t time.Time, rev string) (string, error) {
+	if len(rev) > 12 {
+		rev = rev[:12]
+	}
+	segment := t.UTC().Format(pseudoTimeFormat) + "-" + rev
+
+	if base == "" {
+		if major == "" {
+			major = "v0"
+		}
+		if _, err := strconv.ParseUint(strings.TrimPrefix(major, "v"), 10, 64); err != nil || !strings.HasPrefix(major, "v") {
+			return "", fmt.Errorf("invalid major version %q", major)
+		}
+		return major + ".0.0-" + segment, nil
+	}
+
+	if !strings.HasPrefix(base, "v") {
+		return "", fmt.Errorf("base version %s must have a v prefix", base)
+	}
+
+	v, err := ParseVersion(base[1:], Strict)
+	if err != nil {
+		return "", err
+	}
+
+	build := ""
+	if v.Metadata() != "" {
+		build = "+" + v.Metadata()
+	}
+
+	if len(v.Prerelease()) > 0 {
+		return fmt.Sprintf("v%d.%d.%d-%s.0.%s%s", v.Major(), v.Minor(), v.Patch(), strings.Join(v.Prerelease(), "."), segment, build), nil
+	}
+	return fmt.Sprintf("v%d.%d.%d-0.%s%s", v.Major(), v.Minor(), v.Patch()+1, segment, build), nil
+}
+
+// PathMajor returns the major version suffix of a Go module path, such as
+// "/v2" for example.com/mod/v2 or ".v3" for gopkg.in/yaml.v3, or an empty
+// string if there is none
+func PathMajor(path string) string {
+	if strings.HasPrefix(path, "gopkg.in/") {
+		if i := strings.LastIndex(path, ".v"); i > 0 && isMajor(path[i+2:], true) {
+			return path[i:]
+		}
+		return ""
+	}
+
+	if i := strings.LastIndex(path, "/v"); i > 0 && isMajor(path[i+2:], false) {
+		return path[i:]
+	}
+	return ""
+}
+
+// isMajor reports whether s is a major version number allowed in a module
+// path suffix
+func isMajor(s string, low bool) bool {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || (len(s) > 1 && s[0] == '0') {
+		return false
+	}
+	return low || n >= 2
+}
+
+// CheckPathMajor returns an error if a version can't be a version of the Go
+// module with a path, following the /vN major version suffix rule: versions
+// v2 and above require the path to end in a matching /vN, and v0 and v1
+// require it not to. Versions with incompatible build metadata are exempt.
+func CheckPathMajor(version string, path string) error {
+	v, err := NewVersion(version)
+	if err != nil {
+		return err
+	}
+
+	major := fmt.Sprintf("v%d", v.Major())
+	pm := PathMajor(path)
+
+	if strings.HasPrefix(path, "gopkg.in/") {
+		if pm == "" || pm[1:] != major {
+			return fmt.Errorf("version %s requires module path %s to end in .%s", version, path, major)
+		}
+		return nil
+	}
+
+	if v.Major() >= 2 && pm == "" && v.Metadata() == "incompatible" {
+		return nil
+	}
+
+	if v.Major() < 2 {
+		if pm != "" {
+			return fmt.Errorf("version %s requires module path %s not to end in %s", version, path, pm)
+		}
+		return nil
+	}
+
+	if pm != "/"+major {
+		return fmt.Errorf("version %s requires module path %s to end in /%s", version, path, major)
+	}
+	return nil
+}
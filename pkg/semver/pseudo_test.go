@@ -0,0 +1,94 @@
+package semver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPseudoVersion(t *testing.T) {
+	when := time.Date(2022, time.September, 19, 9, 18, 48, 0, time.UTC)
+	rev := "fb04ddd9f9c8a1b2c3d4e5f60718293a4b5c6d7e"
+
+	tests := []struct {
+		major   string
+		base    string
+		version string
+	}{
+		{"", "", "v0.0.0-20220919091848-fb04ddd9f9c8"},
+		{"v2", "", "v2.0.0-20220919091848-fb04ddd9f9c8"},
+		{"", "v1.2.3", "v1.2.4-0.20220919091848-fb04ddd9f9c8"},
+		{"", "v1.2.3-rc.1", "v1.2.3-rc.1.0.20220919091848-fb04ddd9f9c8"},
+		{"", "v2.1.0+incompatible", "v2.1.1-0.20220919091848-fb04ddd9f9c8+incompatible"},
+	}
+
+	for _, tc := range tests {
+		v, err := NewPseudoVersion(tc.major, tc.base, when.In(time.FixedZone("CEST", 7200)), rev)
+		if err != nil {
+			t.Fatalf("error for base %q: %s", tc.base, err)
+		}
+
+		if v != tc.version {
+			t.Fatalf("expected pseudo-version of base %q to be %s, but got %s", tc.base, tc.version, v)
+		}
+
+		if !IsPseudoVersion(v) {
+			t.Fatalf("expected %s to be a pseudo-version", v)
+		}
+
+		p, err := ParsePseudoVersion(v)
+		if err != nil {
+			t.Fatalf("error parsing %s: %s", v, err)
+		}
+
+		base := tc.base
+		if i := len(base) - len("+incompatible"); p.Build != "" && i > 0 {
+			base = base[:i]
+		}
+
+		if p.Base != base || !p.Time.Equal(when) || p.Revision != rev[:12] {
+			t.Fatalf("expected %s to parse to base %q, time %s and revision %s, but got %+v", v, base, when, rev[:12], p)
+		}
+	}
+
+	for _, v := range []string{"v1.2.3", "v1.2.3-rc.1", "1.2.4-0.20220919091848-fb04ddd9f9c8", "v1.2.4-20220919091848-fb04ddd9f9c8", "v1.2.0-0.20220919091848-fb04ddd9f9c8", "v0.0.0-20221319091848-fb04ddd9f9c8"} {
+		if _, err := ParsePseudoVersion(v); err == nil {
+			t.Fatalf("expected %s not to be a valid pseudo-version", v)
+		}
+	}
+
+	for _, base := range []string{"1.2.3", "v1.2", "v01.2.3"} {
+		if _, err := NewPseudoVersion("", base, when, rev); err == nil {
+			t.Fatalf("expected base %s to be invalid", base)
+		}
+	}
+}
+
+func TestCheckPathMajor(t *testing.T) {
+	tests := []struct {
+		version string
+		path    string
+		valid   bool
+	}{
+		{"v1.9.0", "example.com/mod", true},
+		{"v0.1.0", "example.com/mod", true},
+		{"v2.0.0", "example.com/mod", false},
+		{"v2.0.0", "example.com/mod/v2", true},
+		{"v3.0.0", "example.com/mod/v2", false},
+		{"v1.0.0", "example.com/mod/v2", false},
+		{"v2.0.0+incompatible", "example.com/mod", true},
+		{"v3.0.0", "gopkg.in/yaml.v3", true},
+		{"v2.4.0", "gopkg.in/yaml.v3", false},
+		{"v1.0.0", "example.com/mod/v1", true},
+		{"v2.0.0", "example.com/mod/v02", false},
+	}
+
+	for _, tc := range tests {
+		err := CheckPathMajor(tc.version, tc.path)
+		if tc.valid && err != nil {
+			t.Fatalf("expected %s to be a valid version of %s, but got: %s", tc.version, tc.path, err)
+		}
+		if !tc.valid && err == nil {
+			t.Fatalf("expected %s not to be a valid version of %s", tc.version, tc.path)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package semver
+
+import (
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Range is a parsed version range. Ranges use the npm style syntax, e.g.
+// ">=1.2.0 <2.0.0 || ^3.1", "~1.2" or "1.2 - 1.4".
+type Range struct {
+	c *semver.Constraints
+}
+
+// NewRange parses a range or returns an error if it's not valid
+func NewRange(rng string) (*Range, error) {
+	c, err := semver.NewConstraint(rng)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Range{c: c}, nil
+}
+
+// Check reports whether a version satisfies the range
+func (r *Range) Check(v *Version) bool {
+	return r.c.Check(v.v)
+}
+
+// String returns the range as it was provided
+func (r *Range) String() string {
+	return r.c.String()
+}
+
+// Satisfies reports whether a version satisfies a range
+func Satisfies(in string, rng string) (bool, error) {
+	r, err := NewRange(rng)
+	if err != nil {
+		return false, err
+	}
+
+	v, err := NewVersion(in)
+	if err != nil {
+		return false, err
+	}
+
+	return r.Check(v), nil
+}
+
+// satisfying returns the sorted valid versions that satisfy a range
+func satisfying(in []string, rng string) (Collection, error) {
+	r, err := NewRange(rng)
+	if err != nil {
+		return nil, err
+	}
+
+	vs := make(Collection, 0)
+	for _, v := range Versions(in) {
+		if r.Check(v) {
+			vs = append(vs, v)
+		}
+	}
+	sort.Sort(vs)
+	return vs, nil
+}
+
+// Satisfying takes a collection of raw version values and returns a sorted
+// list of the valid versions that satisfy a range
+func Satisfying(in []string, rng string) ([]string, error) {
+	vs, err := satisfying(in, rng)
+	if err != nil || len(vs) == 0 {
+		return nil, err
+	}
+
+	r := make([]string, len(vs))
+	for i, v := range vs {
+		r[i] = v.String()
+	}
+	return r, nil
+}
+
+// MaxSatisfying returns the highest version that satisfies a range or an
+// empty string if none do
+func MaxSatisfying(in []string, rng string) (string, error) {
+	vs, err := satisfying(in, rng)
+	if err != nil || len(vs) == 0 {
+		return "", err
+	}
+
+	return vs[len(vs)-1].String(), nil
+}
+
+// MinSatisfying returns the lowest version that satisfies a range or an
+// empty string if none do
+func MinSatisfying(in []string, rng string) (string, error) {
+	vs, err := satisfying(in, rng)
+	if err != nil || len(vs) == 0 {
+		return "", err
+	}
+
+	return vs[0].String(), nil
+}
@@ -0,0 +1,83 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestSatisfies(t *testing.T) {
+	tests := []struct {
+		version  string
+		rng      string
+		expected bool
+		err      bool
+	}{
+		{"1.2.0", ">=1.2.0 <2.0.0 || ^3.1", true, false},
+		{"2.0.0", ">=1.2.0 <2.0.0 || ^3.1", false, false},
+		{"3.4.1", ">=1.2.0 <2.0.0 || ^3.1", true, false},
+		{"3.5.0-rc.1", ">=1.2.0 <2.0.0 || ^3.1", false, false},
+		{"v1.3", "~1.3", true, false},
+		{"1.4.9", "1.2 - 1.4", true, false},
+		{"1.5.0", "1.2 - 1.4", false, false},
+		{"1.2.beta", "^1.0", false, true},
+		{"1.2.3", "foo", false, true},
+	}
+
+	for _, tc := range tests {
+		ok, err := Satisfies(tc.version, tc.rng)
+		if tc.err && err == nil {
+			t.Fatalf("expected error for version %s and range %s", tc.version, tc.rng)
+		} else if !tc.err && err != nil {
+			t.Fatalf("error for version %s and range %s: %s", tc.version, tc.rng, err)
+		}
+
+		if ok != tc.expected {
+			t.Fatalf("expected version %s satisfying range %s to be %t", tc.version, tc.rng, tc.expected)
+		}
+	}
+}
+
+func TestSatisfying(t *testing.T) {
+	raw := []string{"v3.1.0", "1.1.0", "1.2.0", "foo", "1.9.9", "2.0.0", "3.2.0-beta.0", "v1.5"}
+
+	tests := []struct {
+		rng      string
+		versions []string
+		min      string
+		max      string
+	}{
+		{">=1.2.0 <2.0.0 || ^3.1", []string{"1.2.0", "1.5.0", "1.9.9", "3.1.0"}, "1.2.0", "3.1.0"},
+		{"^1.5", []string{"1.5.0", "1.9.9"}, "1.5.0", "1.9.9"},
+		{">=4", nil, "", ""},
+	}
+
+	for _, tc := range tests {
+		m, err := Satisfying(raw, tc.rng)
+		if err != nil {
+			t.Fatalf("error for range %s: %s", tc.rng, err)
+		}
+
+		if !Equal(tc.versions, m) {
+			t.Fatalf("expected range %s to return %v, but got %v", tc.rng, tc.versions, m)
+		}
+
+		min, err := MinSatisfying(raw, tc.rng)
+		if err != nil {
+			t.Fatalf("error for range %s: %s", tc.rng, err)
+		}
+		if min != tc.min {
+			t.Fatalf("expected range %s to have min version %q, but got %q", tc.rng, tc.min, min)
+		}
+
+		max, err := MaxSatisfying(raw, tc.rng)
+		if err != nil {
+			t.Fatalf("error for range %s: %s", tc.rng, err)
+		}
+		if max != tc.max {
+			t.Fatalf("expected range %s to have max version %q, but got %q", tc.rng, tc.max, max)
+		}
+	}
+
+	if _, err := Satisfying(raw, "foo"); err == nil {
+		t.Fatal("expected error for invalid range")
+	}
+}
@@ -0,0 +1,164 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnsupportedReleaseType is returned when a scheme can't increment a
+// version by a release type
+var ErrUnsupportedReleaseType = errors.New("release type is not supported by the version scheme")
+
+// Ordered is a parsed version of a scheme that can be compared with other
+// versions of the same scheme
+type Ordered interface {
+	// String returns the normalized representation of the version
+	String() string
+	// Original returns the raw value the version was parsed from
+	Original() string
+	// Compare returns -1, 0 or 1 depending on whether the version is lower
+	// than, equal to or greater than the other version
+	Compare(o Ordered) int
+}
+
+// Scheme parses, orders and increments the versions of a versioning scheme
+type Scheme interface {
+	// Name returns the name the scheme is selected by, e.g. semver or calver
+	Name() string
+	// Parse parses a raw version value and returns an error if it's not valid
+	Parse(in string) (Ordered, error)
+	// Increment returns the version incremented by the release type
+	Increment(in string, rt ReleaseType, ident string) (string, error)
+}
+
+// SchemeFunc returns a scheme for a format, such as YYYY.0M.MICRO for calver.
+// Schemes without formats are given an empty format.
+type SchemeFunc func(format string) (Scheme, error)
+
+// schemes are the registered schemes by name
+var schemes = map[string]SchemeFunc{
+	"semver": func(format string) (Scheme, error) {
+		if format != "" {
+			return nil, errors.New("semver doesn't take a format")
+		}
+		return SemVer, nil
+	},
+	"calver": func(format string) (Scheme, error) {
+		return NewCalVer(format)
+	},
+	"pep440": func(format string) (Scheme, error) {
+		if format != "" {
+			return nil, errors.New("pep440 doesn't take a format")
+		}
+		return PEP440, nil
+	},
+	"deb": func(format string) (Scheme, error) {
+		if format != "" {
+			return nil, errors.New("deb doesn't take a format")
+		}
+		return Debian, nil
+	},
+	"rpm": func(format string) (Scheme, error) {
+		if format != "" {
+			return nil, errors.New("rpm doesn't take a format")
+		}
+		return RPM, nil
+	},
+	"maven": func(format string) (Scheme, error) {
+		if format != "" {
+			return nil, errors.New("maven doesn't take a format")
+		}
+		return Maven, nil
+	},
+}
+
+// RegisterScheme makes a scheme available by name to NewScheme, replacing any
+// scheme registered with the same name
+func RegisterScheme(name string, f SchemeFunc) {
+	schemes[strings.ToLower(name)] = f
+}
+
+// SchemeNames returns the names of the registered schemes, sorted
+func SchemeNames() []string {
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewScheme returns the registered scheme with a name for a format
+func NewScheme(name string, format string) (Scheme, error) {
+	f, ok := schemes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("unknown version scheme %q, must be one of: %s", name, strings.Join(SchemeNames(), ", "))
+	}
+	return f(format)
+}
+
+// SemVer is the semantic versioning scheme, see https://semver.org
+var SemVer Scheme = semverScheme{}
+
+type semverScheme struct{}
+
+// semverOrdered is a semantic version ordered by precedence
+type semverOrdered struct {
+	*Version
+}
+
+func (semverScheme) Name() string {
+	return "semver"
+}
+
+func (semverScheme) Parse(in string) (Ordered, error) {
+	v, err := NewVersion(in)
+	if err != nil {
+		return nil, err
+	}
+	return semverOrdered{v}, nil
+}
+
+func (semverScheme) Increment(in string, rt ReleaseType, ident string) (string, error) {
+	return Increment(in, rt, ident)
+}
+
+func (v semverOrdered) Compare(o Ordered) int {
+	ov, ok := o.(semverOrdered)
+	if !ok {
+		return strings.Compare(v.String(), o.String())
+	}
+	return v.Version.Compare(ov.Version)
+}
+
+// SortedBy takes a collection of raw version values and returns the versions
+// that are valid in a scheme, sorted. Versions of the same precedence keep
+// the order they were provided in.
+func SortedBy(s Scheme, in []string) []Ordered {
+	vs := make([]Ordered, 0, len(in))
+	for _, r := range in {
+		v, err := s.Parse(r)
+		if err != nil {
+			continue
+		}
+		vs = append(vs, v)
+	}
+
+	sort.SliceStable(vs, func(i, j int) bool {
+		return vs[i].Compare(vs[j]) < 0
+	})
+	return vs
+}
+
+// SortedListBy takes a collection of raw version values and returns a sorted
+// list of the normalized versions that are valid in a scheme
+func SortedListBy(s Scheme, in []string) []string {
+	vs := SortedBy(s, in)
+	r := make([]string, len(vs))
+	for i, v := range vs {
+		r[i] = v.String()
+	}
+	return r
+}
@@ -10,16 +10,26 @@ import (
 	"github.com/Masterminds/semver/v3"
 )
 
+// ReleaseType identifies the component of a version that an increment changes
 type ReleaseType int
 
 const (
-	major ReleaseType = iota
-	minor
-	patch
-	preMajor
-	preMinor
-	prePatch
-	preRelease
+	// Major increments the major version
+	Major ReleaseType = iota
+	// Minor increments the minor version
+	Minor
+	// Patch increments the patch version
+	Patch
+	// PreMajor increments the major version and starts a prerelease
+	PreMajor
+	// PreMinor increments the minor version and starts a prerelease
+	PreMinor
+	// PrePatch increments the patch version and starts a prerelease
+	PrePatch
+	// PreRelease increments the prerelease, or acts like PrePatch on a release version
+	PreRelease
+	// Build identifies a change in build metadata only. It is reported by Diff
+	Build
 	pre // should not be referenced externally
 )
 
@@ -30,9 +40,15 @@ var (
 	ErrUnknownReleaseType = errors.New("unknown release type")
 )
 
+// releaseTypeNames are the string representations of the release types
+var releaseTypeNames = [...]string{"major", "minor", "patch", "premajor", "preminor", "prepatch", "prerelease", "build", "pre"}
+
 // String is the string representation of a ReleaseType
 func (t ReleaseType) String() string {
-	return [...]string{"major", "minor", "patch", "premajor", "preminor", "prepatch", "prerelease", "pre"}[t]
+	if t < 0 || int(t) >= len(releaseTypeNames) {
+		return fmt.Sprintf("ReleaseType(%d)", int(t))
+	}
+	return releaseTypeNames[t]
 }
 
 // ToReleaseType is a convenience function for getting a valid ReleaseType
@@ -43,19 +59,21 @@ func ToReleaseType(rt string) (ReleaseType, error) {
 	trm := strings.TrimSpace(rt)
 	switch strings.ToLower(trm) {
 	case "major":
-		rtn = major
+		rtn = Major
 	case "minor":
-		rtn = minor
+		rtn = Minor
 	case "patch":
-		rtn = patch
+		rtn = Patch
 	case "premajor":
-		rtn = preMajor
+		rtn = PreMajor
 	case "preminor":
-		rtn = preMinor
+		rtn = PreMinor
 	case "prepatch":
-		rtn = prePatch
+		rtn = PrePatch
 	case "prerelease":
-		rtn = preRelease
+		rtn = PreRelease
+	case "build":
+		rtn = Build
 	case "pre":
 		err = ErrInternalOnlyReleaseType
 	default:
@@ -77,7 +95,21 @@ func Valid(in string) (string, error) {
 
 // Increment returns the version incremented by the release type. This function
 // largely mimics the increment logic found in https://github.com/npm/node-semver
+// The result is normalized, except that it keeps the "v" prefix of the input.
 func Increment(in string, rt ReleaseType, ident string) (string, error) {
+	rtn, err := increment(in, rt, ident)
+	if err != nil {
+		return "", err
+	}
+
+	if rtn != "" && strings.HasPrefix(in, "v") {
+		rtn = "v" + rtn
+	}
+	return rtn, nil
+}
+
+// increment returns the normalized version incremented by the release type
+func increment(in string, rt ReleaseType, ident string) (string, error) {
 	v, err := semver.NewVersion(in)
 	if err != nil {
 		return "", err
@@ -87,30 +119,30 @@ func Increment(in string, rt ReleaseType, ident string) (string, error) {
 	var rtn string
 
 	switch rt {
-	case preMajor:
+	case PreMajor:
 		v2 := v.IncMajor()
-		rtn, _ = Increment(v2.String(), pre, ident)
+		rtn, _ = increment(v2.String(), pre, ident)
 
-	case preMinor:
+	case PreMinor:
 		v2 := v.IncMinor()
-		rtn, _ = Increment(v2.String(), pre, ident)
+		rtn, _ = increment(v2.String(), pre, ident)
 
-	case prePatch:
+	case PrePatch:
 		v2, err := v.SetPrerelease("")
 		if err != nil {
 			return "", nil
 		}
 		v3 := v2.IncPatch()
-		rtn, _ = Increment(v3.String(), pre, ident)
+		rtn, _ = increment(v3.String(), pre, ident)
 
-	case preRelease:
+	case PreRelease:
 		// if the input is a non-prerelease version, this acts the same as prepatch
 		if len(prerelease) == 0 {
-			in, _ = Increment(in, patch, ident)
+			in, _ = increment(in, Patch, ident)
 		}
-		rtn, _ = Increment(in, pre, ident)
+		rtn, _ = increment(in, pre, ident)
 
-	case major:
+	case Major:
 		// if this is a pre-major version, bump up to the same major version.
 		// Otherwise, increment major
 		// 1.0.0-5 bumps to 1.0.0
@@ -125,7 +157,7 @@ func Increment(in string, rt ReleaseType, ident string) (string, error) {
 			rtn = v2.String()
 		}
 
-	case minor:
+	case Minor:
 		// If this is a pre-minor version, bump up to the same minor version.
 		// Otherwise increment minor.
 		// 1.2.0-5 bumps to 1.2.0
@@ -140,9 +172,41 @@ func Increment(in string, rt ReleaseType, ident string) (string, error) {
 			rtn = v2.String()
 		}
 
-	case patch:
+	case Patch:
 		rtn = v.IncPatch().String()
 
+	case Build:
+		// increment the last numeric build identifier, or start one with the
+		// identifier when the build metadata doesn't start with it
+		// 1.2.3+build.5 bumps to 1.2.3+build.6
+		// 1.2.3 bumps to 1.2.3+1, or 1.2.3+build.1 with the build identifier
+		var meta []string
+		if m := v.Metadata(); m != "" {
+			meta = strings.Split(m, ".")
+		}
+		if ident != "" && (len(meta) == 0 || meta[0] != ident) {
+			meta = []string{ident}
+		}
+
+		i := len(meta) - 1
+		for ; i >= 0; i-- {
+			if nv, err := strconv.Atoi(meta[i]); err == nil {
+				meta[i] = strconv.Itoa(nv + 1)
+				break
+			}
+		}
+
+		// didn't increment anything
+		if i < 0 {
+			meta = append(meta, "1")
+		}
+
+		nv, err := v.SetMetadata(strings.Join(meta, "."))
+		if err != nil {
+			return "", err
+		}
+		rtn = nv.String()
+
 	case pre:
 		if len(prerelease) == 0 {
 			prerelease = []string{"0"}
@@ -181,61 +245,36 @@ func Increment(in string, rt ReleaseType, ident string) (string, error) {
 			nv, _ := v.SetPrerelease(strings.Join(prerelease, "."))
 			rtn = nv.String()
 		}
-	}
-
-	return rtn, nil
-}
 
-// Major returns the major version number
-func Major(in string) (uint64, error) {
-	v, err := semver.NewVersion(in)
-	if err != nil {
-		return 0, err
-	}
-
-	return v.Major(), nil
-}
-
-// Minor returns the minor version number
-func Minor(in string) (uint64, error) {
-	v, err := semver.NewVersion(in)
-	if err != nil {
-		return 0, err
-	}
-
-	return v.Minor(), nil
-}
-
-// Patch returns the patch version number
-func Patch(in string) (uint64, error) {
-	v, err := semver.NewVersion(in)
-	if err != nil {
-		return 0, err
+	default:
+		return "", fmt.Errorf("%s: %w", rt, ErrUnknownReleaseType)
 	}
 
-	return v.Patch(), nil
+	return rtn, nil
 }
 
 // Prerelease returns an array of prerelease components or nil if none exist
 func Prerelease(in string) ([]string, error) {
-	v, err := semver.NewVersion(in)
+	v, err := NewVersion(in)
 	if err != nil {
 		return nil, err
 	}
 
-	var eparts []string
-	pre := v.Prerelease()
-	if len(pre) > 0 {
-		eparts = strings.Split(pre, ".")
-	}
-	return eparts, nil
+	return v.Prerelease(), nil
 }
 
-// List takes a collection of raw version values and returns a list of valid versions
-func list(in []string) []*semver.Version {
-	vs := make([]*semver.Version, 0)
+// Versions takes a collection of raw version values and returns the valid
+// versions, in the order they were provided
+func Versions(in []string) Collection {
+	return ParseVersions(in, Loose)
+}
+
+// ParseVersions takes a collection of raw version values and returns the
+// versions that are valid in the parse mode, in the order they were provided
+func ParseVersions(in []string, mode ParseMode) Collection {
+	vs := make(Collection, 0)
 	for _, r := range in {
-		v, err := semver.NewVersion(r)
+		v, err := ParseVersion(r, mode)
 		if err != nil {
 			continue
 		}
@@ -246,7 +285,7 @@ func list(in []string) []*semver.Version {
 
 // List takes a collection of raw version values and returns a list of valid versions
 func List(in []string) ([]string, error) {
-	vs := list(in)
+	vs := Versions(in)
 	if len(vs) == 0 {
 		return nil, nil
 	}
@@ -258,14 +297,24 @@ func List(in []string) ([]string, error) {
 	return r, nil
 }
 
+// SortedVersions takes a collection of raw version values and returns the
+// valid versions, sorted
+func SortedVersions(in []string) Collection {
+	vs := Versions(in)
+
+	// versions differing only in build metadata have the same precedence and
+	// keep the order they were provided in
+	sort.Stable(vs)
+	return vs
+}
+
 // SortedList takes a collection of raw version values and returns a sorted list of valid versions
 func SortedList(in []string) ([]string, error) {
-	vs := list(in)
+	vs := SortedVersions(in)
 	if len(vs) == 0 {
 		return nil, nil
 	}
 
-	sort.Sort(semver.Collection(vs))
 	r := make([]string, len(vs))
 	for i, v := range vs {
 		r[i] = v.String()
@@ -12,14 +12,17 @@ func TestReleaseTypes(t *testing.T) {
 		rt       ReleaseType
 		expected string
 	}{
-		{major, "major"},
-		{minor, "minor"},
-		{patch, "patch"},
-		{preMajor, "premajor"},
-		{preMinor, "preminor"},
-		{prePatch, "prepatch"},
-		{preRelease, "prerelease"},
+		{Major, "major"},
+		{Minor, "minor"},
+		{Patch, "patch"},
+		{PreMajor, "premajor"},
+		{PreMinor, "preminor"},
+		{PrePatch, "prepatch"},
+		{PreRelease, "prerelease"},
+		{Build, "build"},
 		{pre, "pre"},
+		{ReleaseType(42), "ReleaseType(42)"},
+		{ReleaseType(-1), "ReleaseType(-1)"},
 	}
 
 	for _, tc := range tests {
@@ -86,84 +89,100 @@ func TestIncrement(t *testing.T) {
 		expectedErr     error
 	}{
 
-		{"1.2.3", major, "", "2.0.0", nil},
-		{"1.2.3", minor, "", "1.3.0", nil},
-		{"1.2.3", patch, "", "1.2.4", nil},
-		{"1.2.3tag", major, "", "", semver.ErrInvalidSemVer},
-		{"1.2.3-tag", major, "", "2.0.0", nil},
-		{"1.2.0-0", patch, "", "1.2.0", nil},
-		{"1.2.3-4", major, "", "2.0.0", nil},
-		{"1.2.3-4", minor, "", "1.3.0", nil},
-		{"1.2.3-4", patch, "", "1.2.3", nil},
-		{"1.2.3-alpha.0.beta", major, "", "2.0.0", nil},
-		{"1.2.3-alpha.0.beta", minor, "", "1.3.0", nil},
-		{"1.2.3-alpha.0.beta", patch, "", "1.2.3", nil},
-		{"1.2.4", preRelease, "", "1.2.5-0", nil},
-		{"1.2.3-0", preRelease, "", "1.2.3-1", nil},
-		{"1.2.3-alpha.0", preRelease, "", "1.2.3-alpha.1", nil},
-		{"1.2.3-alpha.1", preRelease, "", "1.2.3-alpha.2", nil},
-		{"1.2.3-alpha.2", preRelease, "", "1.2.3-alpha.3", nil},
-		{"1.2.3-alpha.0.beta", preRelease, "", "1.2.3-alpha.1.beta", nil},
-		{"1.2.3-alpha.1.beta", preRelease, "", "1.2.3-alpha.2.beta", nil},
-		{"1.2.3-alpha.2.beta", preRelease, "", "1.2.3-alpha.3.beta", nil},
-		{"1.2.3-alpha.10.0.beta", preRelease, "", "1.2.3-alpha.10.1.beta", nil},
-		{"1.2.3-alpha.10.1.beta", preRelease, "", "1.2.3-alpha.10.2.beta", nil},
-		{"1.2.3-alpha.10.2.beta", preRelease, "", "1.2.3-alpha.10.3.beta", nil},
-		{"1.2.3-alpha.10.beta.0", preRelease, "", "1.2.3-alpha.10.beta.1", nil},
-		{"1.2.3-alpha.10.beta.1", preRelease, "", "1.2.3-alpha.10.beta.2", nil},
-		{"1.2.3-alpha.10.beta.2", preRelease, "", "1.2.3-alpha.10.beta.3", nil},
-		{"1.2.3-alpha.9.beta", preRelease, "", "1.2.3-alpha.10.beta", nil},
-		{"1.2.3-alpha.10.beta", preRelease, "", "1.2.3-alpha.11.beta", nil},
-		{"1.2.3-alpha.11.beta", preRelease, "", "1.2.3-alpha.12.beta", nil},
-		{"1.2.0", prePatch, "", "1.2.1-0", nil},
-		{"1.2.0-1", prePatch, "", "1.2.1-0", nil},
-		{"1.2.0", preMinor, "", "1.3.0-0", nil},
-		{"1.2.3-1", preMinor, "", "1.3.0-0", nil},
-		{"1.2.0", preMajor, "", "2.0.0-0", nil},
-		{"1.2.3-1", preMajor, "", "2.0.0-0", nil},
-		{"1.2.0-1", minor, "", "1.2.0", nil},
-		{"1.0.0-1", major, "", "1.0.0", nil},
-
-		{"1.2.3", major, "", "2.0.0", nil},
-		{"1.2.3", minor, "", "1.3.0", nil},
-		{"1.2.3", patch, "", "1.2.4", nil},
-		{"1.2.3tag", major, "", "", semver.ErrInvalidSemVer},
-		{"1.2.3-tag", major, "", "2.0.0", nil},
-		{"1.2.0-0", patch, "", "1.2.0", nil},
-		{"fake", major, "", "", semver.ErrInvalidSemVer},
-		{"1.2.3-4", major, "", "2.0.0", nil},
-		{"1.2.3-4", minor, "", "1.3.0", nil},
-		{"1.2.3-4", patch, "", "1.2.3", nil},
-		{"1.2.3-alpha.0.beta", major, "", "2.0.0", nil},
-		{"1.2.3-alpha.0.beta", minor, "", "1.3.0", nil},
-		{"1.2.3-alpha.0.beta", patch, "", "1.2.3", nil},
-		{"1.2.4", preRelease, "dev", "1.2.5-dev.0", nil},
-		{"1.2.3-0", preRelease, "dev", "1.2.3-dev.0", nil},
-		{"1.2.3-alpha.0", preRelease, "dev", "1.2.3-dev.0", nil},
-		{"1.2.3-alpha.0", preRelease, "", "1.2.3-alpha.1", nil},
-		{"1.2.3-alpha.0.beta", preRelease, "dev", "1.2.3-dev.0", nil},
-		{"1.2.3-alpha.0.beta", preRelease, "", "1.2.3-alpha.1.beta", nil},
-		{"1.2.3-alpha.10.0.beta", preRelease, "dev", "1.2.3-dev.0", nil},
-		{"1.2.3-alpha.10.0.beta", preRelease, "", "1.2.3-alpha.10.1.beta", nil},
-		{"1.2.3-alpha.10.1.beta", preRelease, "", "1.2.3-alpha.10.2.beta", nil},
-		{"1.2.3-alpha.10.2.beta", preRelease, "", "1.2.3-alpha.10.3.beta", nil},
-		{"1.2.3-alpha.10.beta.0", preRelease, "dev", "1.2.3-dev.0", nil},
-		{"1.2.3-alpha.10.beta.0", preRelease, "", "1.2.3-alpha.10.beta.1", nil},
-		{"1.2.3-alpha.10.beta.1", preRelease, "", "1.2.3-alpha.10.beta.2", nil},
-		{"1.2.3-alpha.10.beta.2", preRelease, "", "1.2.3-alpha.10.beta.3", nil},
-		{"1.2.3-alpha.9.beta", preRelease, "dev", "1.2.3-dev.0", nil},
-		{"1.2.3-alpha.9.beta", preRelease, "", "1.2.3-alpha.10.beta", nil},
-		{"1.2.3-alpha.10.beta", preRelease, "", "1.2.3-alpha.11.beta", nil},
-		{"1.2.3-alpha.11.beta", preRelease, "", "1.2.3-alpha.12.beta", nil},
-		{"1.2.0", prePatch, "dev", "1.2.1-dev.0", nil},
-		{"1.2.0-1", prePatch, "dev", "1.2.1-dev.0", nil},
-		{"1.2.0", preMinor, "dev", "1.3.0-dev.0", nil},
-		{"1.2.3-1", preMinor, "dev", "1.3.0-dev.0", nil},
-		{"1.2.0", preMajor, "dev", "2.0.0-dev.0", nil},
-		{"1.2.3-1", preMajor, "dev", "2.0.0-dev.0", nil},
-		{"1.2.0-1", minor, "dev", "1.2.0", nil},
-		{"1.0.0-1", major, "dev", "1.0.0", nil},
-		{"1.2.3-dev.bar", preRelease, "dev", "1.2.3-dev.0", nil},
+		{"1.2.3", Major, "", "2.0.0", nil},
+		{"1.2.3", Minor, "", "1.3.0", nil},
+		{"1.2.3", Patch, "", "1.2.4", nil},
+		{"1.2.3tag", Major, "", "", semver.ErrInvalidSemVer},
+		{"1.2.3-tag", Major, "", "2.0.0", nil},
+		{"1.2.0-0", Patch, "", "1.2.0", nil},
+		{"1.2.3-4", Major, "", "2.0.0", nil},
+		{"1.2.3-4", Minor, "", "1.3.0", nil},
+		{"1.2.3-4", Patch, "", "1.2.3", nil},
+		{"1.2.3-alpha.0.beta", Major, "", "2.0.0", nil},
+		{"1.2.3-alpha.0.beta", Minor, "", "1.3.0", nil},
+		{"1.2.3-alpha.0.beta", Patch, "", "1.2.3", nil},
+		{"1.2.4", PreRelease, "", "1.2.5-0", nil},
+		{"1.2.3-0", PreRelease, "", "1.2.3-1", nil},
+		{"1.2.3-alpha.0", PreRelease, "", "1.2.3-alpha.1", nil},
+		{"1.2.3-alpha.1", PreRelease, "", "1.2.3-alpha.2", nil},
+		{"1.2.3-alpha.2", PreRelease, "", "1.2.3-alpha.3", nil},
+		{"1.2.3-alpha.0.beta", PreRelease, "", "1.2.3-alpha.1.beta", nil},
+		{"1.2.3-alpha.1.beta", PreRelease, "", "1.2.3-alpha.2.beta", nil},
+		{"1.2.3-alpha.2.beta", PreRelease, "", "1.2.3-alpha.3.beta", nil},
+		{"1.2.3-alpha.10.0.beta", PreRelease, "", "1.2.3-alpha.10.1.beta", nil},
+		{"1.2.3-alpha.10.1.beta", PreRelease, "", "1.2.3-alpha.10.2.beta", nil},
+		{"1.2.3-alpha.10.2.beta", PreRelease, "", "1.2.3-alpha.10.3.beta", nil},
+		{"1.2.3-alpha.10.beta.0", PreRelease, "", "1.2.3-alpha.10.beta.1", nil},
+		{"1.2.3-alpha.10.beta.1", PreRelease, "", "1.2.3-alpha.10.beta.2", nil},
+		{"1.2.3-alpha.10.beta.2", PreRelease, "", "1.2.3-alpha.10.beta.3", nil},
+		{"1.2.3-alpha.9.beta", PreRelease, "", "1.2.3-alpha.10.beta", nil},
+		{"1.2.3-alpha.10.beta", PreRelease, "", "1.2.3-alpha.11.beta", nil},
+		{"1.2.3-alpha.11.beta", PreRelease, "", "1.2.3-alpha.12.beta", nil},
+		{"1.2.0", PrePatch, "", "1.2.1-0", nil},
+		{"1.2.0-1", PrePatch, "", "1.2.1-0", nil},
+		{"1.2.0", PreMinor, "", "1.3.0-0", nil},
+		{"1.2.3-1", PreMinor, "", "1.3.0-0", nil},
+		{"1.2.0", PreMajor, "", "2.0.0-0", nil},
+		{"1.2.3-1", PreMajor, "", "2.0.0-0", nil},
+		{"1.2.0-1", Minor, "", "1.2.0", nil},
+		{"1.0.0-1", Major, "", "1.0.0", nil},
+
+		{"1.2.3", Major, "", "2.0.0", nil},
+		{"1.2.3", Minor, "", "1.3.0", nil},
+		{"1.2.3", Patch, "", "1.2.4", nil},
+		{"1.2.3tag", Major, "", "", semver.ErrInvalidSemVer},
+		{"1.2.3-tag", Major, "", "2.0.0", nil},
+		{"1.2.0-0", Patch, "", "1.2.0", nil},
+		{"fake", Major, "", "", semver.ErrInvalidSemVer},
+		{"1.2.3-4", Major, "", "2.0.0", nil},
+		{"1.2.3-4", Minor, "", "1.3.0", nil},
+		{"1.2.3-4", Patch, "", "1.2.3", nil},
+		{"1.2.3-alpha.0.beta", Major, "", "2.0.0", nil},
+		{"1.2.3-alpha.0.beta", Minor, "", "1.3.0", nil},
+		{"1.2.3-alpha.0.beta", Patch, "", "1.2.3", nil},
+		{"1.2.4", PreRelease, "dev", "1.2.5-dev.0", nil},
+		{"1.2.3-0", PreRelease, "dev", "1.2.3-dev.0", nil},
+		{"1.2.3-alpha.0", PreRelease, "dev", "1.2.3-dev.0", nil},
+		{"1.2.3-alpha.0", PreRelease, "", "1.2.3-alpha.1", nil},
+		{"1.2.3-alpha.0.beta", PreRelease, "dev", "1.2.3-dev.0", nil},
+		{"1.2.3-alpha.0.beta", PreRelease, "", "1.2.3-alpha.1.beta", nil},
+		{"1.2.3-alpha.10.0.beta", PreRelease, "dev", "1.2.3-dev.0", nil},
+		{"1.2.3-alpha.10.0.beta", PreRelease, "", "1.2.3-alpha.10.1.beta", nil},
+		{"1.2.3-alpha.10.1.beta", PreRelease, "", "1.2.3-alpha.10.2.beta", nil},
+		{"1.2.3-alpha.10.2.beta", PreRelease, "", "1.2.3-alpha.10.3.beta", nil},
+		{"1.2.3-alpha.10.beta.0", PreRelease, "dev", "1.2.3-dev.0", nil},
+		{"1.2.3-alpha.10.beta.0", PreRelease, "", "1.2.3-alpha.10.beta.1", nil},
+		{"1.2.3-alpha.10.beta.1", PreRelease, "", "1.2.3-alpha.10.beta.2", nil},
+		{"1.2.3-alpha.10.beta.2", PreRelease, "", "1.2.3-alpha.10.beta.3", nil},
+		{"1.2.3-alpha.9.beta", PreRelease, "dev", "1.2.3-dev.0", nil},
+		{"1.2.3-alpha.9.beta", PreRelease, "", "1.2.3-alpha.10.beta", nil},
+		{"1.2.3-alpha.10.beta", PreRelease, "", "1.2.3-alpha.11.beta", nil},
+		{"1.2.3-alpha.11.beta", PreRelease, "", "1.2.3-alpha.12.beta", nil},
+		{"1.2.0", PrePatch, "dev", "1.2.1-dev.0", nil},
+		{"1.2.0-1", PrePatch, "dev", "1.2.1-dev.0", nil},
+		{"1.2.0", PreMinor, "dev", "1.3.0-dev.0", nil},
+		{"1.2.3-1", PreMinor, "dev", "1.3.0-dev.0", nil},
+		{"1.2.0", PreMajor, "dev", "2.0.0-dev.0", nil},
+		{"1.2.3-1", PreMajor, "dev", "2.0.0-dev.0", nil},
+		{"1.2.0-1", Minor, "dev", "1.2.0", nil},
+		{"1.0.0-1", Major, "dev", "1.0.0", nil},
+		{"1.2.3-dev.bar", PreRelease, "dev", "1.2.3-dev.0", nil},
+
+		{"v1.2.3", Major, "", "v2.0.0", nil},
+		{"v1.2.3", Minor, "", "v1.3.0", nil},
+		{"v1", Patch, "", "v1.0.1", nil},
+		{"v1.2.4", PreRelease, "", "v1.2.5-0", nil},
+		{"v1.2.3-alpha.0", PreRelease, "", "v1.2.3-alpha.1", nil},
+		{"v1.2.0", PreMinor, "rc", "v1.3.0-rc.0", nil},
+		{"1.2.3", Build, "", "1.2.3+1", nil},
+		{"1.2.3", Build, "build", "1.2.3+build.1", nil},
+		{"1.2.3+build.5", Build, "", "1.2.3+build.6", nil},
+		{"1.2.3+build.5", Build, "build", "1.2.3+build.6", nil},
+		{"1.2.3+sha.abc", Build, "", "1.2.3+sha.abc.1", nil},
+		{"1.2.3+sha.abc", Build, "build", "1.2.3+build.1", nil},
+		{"v1.2.3-rc.0+7", Build, "", "v1.2.3-rc.0+8", nil},
+		{"1.2.3+build.5", Patch, "", "1.2.4", nil},
+		{"1.2.3", ReleaseType(42), "", "", fmt.Errorf("ReleaseType(42): %w", ErrUnknownReleaseType)},
 	}
 
 	for _, tc := range tests {
@@ -225,13 +244,18 @@ func TestMajor(t *testing.T) {
 	}
 
 	for _, tc := range tests {
-		m, err := Major(tc.version)
+		v, err := NewVersion(tc.version)
 		if tc.err && err == nil {
 			t.Fatalf("expected error for version: %s", tc.version)
 		} else if !tc.err && err != nil {
 			t.Fatalf("error for version %s: %s", tc.version, err)
 		}
 
+		var m uint64
+		if v != nil {
+			m = v.Major()
+		}
+
 		if tc.major != m {
 			t.Fatalf("expected major version: %d, but got %d", tc.major, m)
 		}
@@ -276,13 +300,18 @@ func TestMinor(t *testing.T) {
 	}
 
 	for _, tc := range tests {
-		m, err := Minor(tc.version)
+		v, err := NewVersion(tc.version)
 		if tc.err && err == nil {
 			t.Fatalf("expected error for version: %s", tc.version)
 		} else if !tc.err && err != nil {
 			t.Fatalf("error for version %s: %s", tc.version, err)
 		}
 
+		var m uint64
+		if v != nil {
+			m = v.Minor()
+		}
+
 		if tc.minor != m {
 			t.Fatalf("expected minor version: %d, but got %d", tc.minor, m)
 		}
@@ -327,13 +356,18 @@ func TestPatch(t *testing.T) {
 	}
 
 	for _, tc := range tests {
-		m, err := Patch(tc.version)
+		v, err := NewVersion(tc.version)
 		if tc.err && err == nil {
 			t.Fatalf("expected error for version: %s", tc.version)
 		} else if !tc.err && err != nil {
 			t.Fatalf("error for version %s: %s", tc.version, err)
 		}
 
+		var m uint64
+		if v != nil {
+			m = v.Patch()
+		}
+
 		if tc.patch != m {
 			t.Fatalf("expected patch version: %d, but got %d", tc.patch, m)
 		}
@@ -459,6 +493,7 @@ func TestSortedList(t *testing.T) {
 		{[]string{"1.2-5", "1.2.3-alpha.01", "v1", "0.2.0", "v3.0.1-beta.0", "v1.2.3.4"}, []string{"0.2.0", "1.0.0", "1.2.0-5", "3.0.1-beta.0"}, false},
 		{[]string{"1.2-5", "1.2.3-alpha.01", "v1", "0.2.0", "v3.0.1-beta.0", "v1.2.3.4", "3.0.1"}, []string{"0.2.0", "1.0.0", "1.2.0-5", "3.0.1-beta.0", "3.0.1"}, false},
 		{[]string{"1.2-5", "1.2.3-alpha.01", "v1", "v4", "0.2.0", "v3.0.1-beta.0", "v1.2.3.4", "3.0.1"}, []string{"0.2.0", "1.0.0", "1.2.0-5", "3.0.1-beta.0", "3.0.1", "4.0.0"}, false},
+		{[]string{"1.0.0+b", "1.0.0+c", "0.9.0", "1.0.0+a", "1.0.0"}, []string{"0.9.0", "1.0.0+b", "1.0.0+c", "1.0.0+a", "1.0.0"}, false},
 	}
 
 	for _, tc := range tests {
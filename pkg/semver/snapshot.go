@@ -0,0 +1,47 @@
+package semver
+
+import (
+	"github.com/Masterminds/semver/v3"
+)
+
+// Snapshot returns a development version of a build past a version, such as
+// 1.4.1-dev.7+g3f2a9c1 for the 7th commit past 1.4.0. The prerelease
+// identifiers start the prerelease of the next patch version of a release, or
+// are appended to the prerelease of a prerelease, so the snapshot sorts after
+// the version and before any version released after it. The build metadata
+// is optional and the result keeps the "v" prefix when the input has one.
+func Snapshot(in string, pre string, meta string) (string, error) {
+	v, err := semver.NewVersion(in)
+	if err != nil {
+		return "", err
+	}
+
+	if pre != "" {
+		if d := identifiers(pre, pre, 0, "prerelease", true); d != nil {
+			return "", d
+		}
+
+		nv := *v
+		if v.Prerelease() == "" {
+			nv = v.IncPatch()
+		} else {
+			pre = v.Prerelease() + "." + pre
+		}
+
+		sv, err := nv.SetPrerelease(pre)
+		if err != nil {
+			return "", err
+		}
+		v = &sv
+	}
+
+	s, err := SetMetadata(v.String(), meta)
+	if err != nil {
+		return "", err
+	}
+
+	if len(in) > 0 && in[0] == 'v' {
+		return "v" + s, nil
+	}
+	return s, nil
+}
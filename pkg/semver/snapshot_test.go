@@ -0,0 +1,51 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	tests := []struct {
+		version  string
+		pre      string
+		meta     string
+		expected string
+	}{
+		{"1.4.0", "dev.7", "g3f2a9c1", "1.4.1-dev.7+g3f2a9c1"},
+		{"v1.4.0", "dev.7", "g3f2a9c1.dirty", "v1.4.1-dev.7+g3f2a9c1.dirty"},
+		{"1.5.0-rc.1", "dev.2", "", "1.5.0-rc.1.dev.2"},
+		{"1.4.0+build.5", "dev.1", "", "1.4.1-dev.1"},
+		{"1.4.0", "", "g3f2a9c1", "1.4.0+g3f2a9c1"},
+	}
+
+	for _, tc := range tests {
+		s, err := Snapshot(tc.version, tc.pre, tc.meta)
+		if err != nil {
+			t.Fatalf("error for version %s: %s", tc.version, err)
+		}
+
+		if s != tc.expected {
+			t.Fatalf("expected snapshot of %s to be %s, but got %s", tc.version, tc.expected, s)
+		}
+	}
+
+	// a snapshot sorts between its version and the versions released after it
+	for _, tc := range [][]string{
+		{"1.4.0", "1.4.1-dev.7+g3f2a9c1", "1.4.1-rc.0", "1.4.1", "1.5.0"},
+		{"1.5.0-rc.1", "1.5.0-rc.1.dev.2", "1.5.0-rc.2", "1.5.0"},
+	} {
+		reversed := make([]string, len(tc))
+		for i, v := range tc {
+			reversed[len(tc)-1-i] = v
+		}
+
+		sorted, _ := SortedList(reversed)
+		if !Equal(sorted, tc) {
+			t.Fatalf("expected %v to sort as %v", sorted, tc)
+		}
+	}
+
+	if _, err := Snapshot("1.4.0", "dev..7", ""); err == nil {
+		t.Fatal("expected an empty prerelease identifier to fail")
+	}
+}
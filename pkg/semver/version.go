@@ -0,0 +1,142 @@
+package semver
+
+import (
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Version is a parsed semantic version
+type Version struct {
+	v *semver.Version
+}
+
+// NewVersion parses a raw version value and returns a Version or an error if
+// it's not valid
+func NewVersion(in string) (*Version, error) {
+	v, err := semver.NewVersion(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Version{v: v}, nil
+}
+
+// ParseVersion parses a raw version value in the parse mode and returns a
+// Version or an error if it's not valid. The error of a value rejected in
+// strict mode is a *Diagnostic.
+func ParseVersion(in string, mode ParseMode) (*Version, error) {
+	if mode == Strict {
+		if d := Diagnose(in, mode); d != nil {
+			return nil, d
+		}
+	}
+
+	return NewVersion(in)
+}
+
+// Major returns the major version number
+func (v *Version) Major() uint64 {
+	return v.v.Major()
+}
+
+// Minor returns the minor version number
+func (v *Version) Minor() uint64 {
+	return v.v.Minor()
+}
+
+// Patch returns the patch version number
+func (v *Version) Patch() uint64 {
+	return v.v.Patch()
+}
+
+// Prerelease returns an array of prerelease components or nil if none exist
+func (v *Version) Prerelease() []string {
+	var eparts []string
+	pre := v.v.Prerelease()
+	if len(pre) > 0 {
+		eparts = strings.Split(pre, ".")
+	}
+	return eparts
+}
+
+// Metadata returns the build metadata or an empty string if none exists
+func (v *Version) Metadata() string {
+	return v.v.Metadata()
+}
+
+// Original returns the raw value the version was parsed from
+func (v *Version) Original() string {
+	return v.v.Original()
+}
+
+// String returns the normalized representation of the version
+func (v *Version) String() string {
+	return v.v.String()
+}
+
+// Prefixed reports whether the raw value the version was parsed from has a
+// "v" prefix
+func (v *Version) Prefixed() bool {
+	return strings.HasPrefix(v.v.Original(), "v")
+}
+
+// Styled returns the normalized representation of the version in the style
+// of the raw value it was parsed from, i.e. with a "v" prefix when the raw
+// value has one
+func (v *Version) Styled() string {
+	if v.Prefixed() {
+		return "v" + v.String()
+	}
+	return v.String()
+}
+
+// Compare returns -1, 0 or 1 depending on whether the version is lower than,
+// equal to or greater than the other version. Build metadata is ignored.
+func (v *Version) Compare(o *Version) int {
+	return v.v.Compare(o.v)
+}
+
+// LessThan tests if the version is lower than the other version
+func (v *Version) LessThan(o *Version) bool {
+	return v.Compare(o) < 0
+}
+
+// GreaterThan tests if the version is greater than the other version
+func (v *Version) GreaterThan(o *Version) bool {
+	return v.Compare(o) > 0
+}
+
+// Equal tests if the version has the same precedence as the other version
+func (v *Version) Equal(o *Version) bool {
+	return v.Compare(o) == 0
+}
+
+// Increment returns a new version incremented by the release type, keeping
+// the "v" prefix of the raw value. See the Increment function for details.
+func (v *Version) Increment(rt ReleaseType, ident string) (*Version, error) {
+	nv, err := Increment(v.Styled(), rt, ident)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewVersion(nv)
+}
+
+// Collection is a list of versions that can be sorted with sort.Sort
+type Collection []*Version
+
+// Len returns the number of versions in the collection
+func (c Collection) Len() int {
+	return len(c)
+}
+
+// Less reports whether the version at index i is lower than the version at index j
+func (c Collection) Less(i, j int) bool {
+	return c[i].LessThan(c[j])
+}
+
+// Swap swaps the versions at indexes i and j
+func (c Collection) Swap(i, j int) {
+	c[i], c[j] = c[j], c[i]
+}
@@ -0,0 +1,142 @@
+package semver
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		version    string
+		normalized string
+		styled     string
+		metadata   string
+	}{
+		{"1.2.3", "1.2.3", "1.2.3", ""},
+		{"v1.2.3", "1.2.3", "v1.2.3", ""},
+		{"v1.2", "1.2.0", "v1.2.0", ""},
+		{"1.2.3-beta.1+build.5", "1.2.3-beta.1+build.5", "1.2.3-beta.1+build.5", "build.5"},
+	}
+
+	for _, tc := range tests {
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Fatalf("error for version %s: %s", tc.version, err)
+		}
+
+		if v.String() != tc.normalized {
+			t.Fatalf("expected version %s to normalize to %s, but got %s", tc.version, tc.normalized, v.String())
+		}
+
+		if v.Styled() != tc.styled {
+			t.Fatalf("expected version %s to be styled as %s, but got %s", tc.version, tc.styled, v.Styled())
+		}
+
+		if v.Original() != tc.version {
+			t.Fatalf("expected original value %s, but got %s", tc.version, v.Original())
+		}
+
+		if v.Metadata() != tc.metadata {
+			t.Fatalf("expected metadata %q, but got %q", tc.metadata, v.Metadata())
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		v1       string
+		v2       string
+		expected int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.3", "v1.2.3", 0},
+		{"1.2.3+build.1", "1.2.3+build.2", 0},
+		{"1.2.3-alpha", "1.2.3", -1},
+		{"1.2.4", "1.2.3", 1},
+		{"2.0.0", "10.0.0", -1},
+	}
+
+	for _, tc := range tests {
+		v1, err := NewVersion(tc.v1)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		v2, err := NewVersion(tc.v2)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if c := v1.Compare(v2); c != tc.expected {
+			t.Fatalf("expected comparing %s to %s to return %d, but got %d", tc.v1, tc.v2, tc.expected, c)
+		}
+	}
+}
+
+func TestVersionIncrement(t *testing.T) {
+	v, err := NewVersion("v1.2.3-rc.0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	nv, err := v.Increment(PreRelease, "rc")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if nv.String() != "1.2.3-rc.1" {
+		t.Fatalf("expected version 1.2.3-rc.1, but got %s", nv)
+	}
+
+	if nv.Original() != "v1.2.3-rc.1" {
+		t.Fatalf("expected original value v1.2.3-rc.1, but got %s", nv.Original())
+	}
+}
+
+func TestCollection(t *testing.T) {
+	vs := Versions([]string{"1.2.3", "foo", "v0.1.0", "1.2.3-alpha", "10.0.0"})
+	sort.Sort(vs)
+
+	expected := []string{"0.1.0", "1.2.3-alpha", "1.2.3", "10.0.0"}
+	if len(vs) != len(expected) {
+		t.Fatalf("expected %d versions, but got %d", len(expected), len(vs))
+	}
+
+	for i, v := range vs {
+		if v.String() != expected[i] {
+			t.Fatalf("expected version %s at index %d, but got %s", expected[i], i, v)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		loose   bool
+		strict  bool
+	}{
+		{"1.2.3", true, true},
+		{"1.2.3-beta.5+build.1", true, true},
+		{"1", true, false},
+		{"v1.0", true, false},
+		{"1.2-beta.5", true, false},
+		{"1.2.3-a..b", false, false},
+		{"1.2.beta", false, false},
+	}
+
+	for _, tc := range tests {
+		for mode, valid := range map[ParseMode]bool{Loose: tc.loose, Strict: tc.strict} {
+			_, err := ParseVersion(tc.version, mode)
+			if valid && err != nil {
+				t.Fatalf("expected version %q to be valid in %s mode, but got: %s", tc.version, mode, err)
+			}
+			if !valid && err == nil {
+				t.Fatalf("expected version %q to be invalid in %s mode", tc.version, mode)
+			}
+		}
+	}
+
+	vs := ParseVersions([]string{"1", "1.2.3", "v1.0", "2.0.0"}, Strict)
+	if len(vs) != 2 || vs[0].String() != "1.2.3" || vs[1].String() != "2.0.0" {
+		t.Fatalf("expected strict versions [1.2.3 2.0.0], but got %v", vs)
+	}
+}